@@ -4,40 +4,90 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"math"
 	"os"
 	"path"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
 )
 
 // AgileKeychain represents a 1password AgileKeychain
 // see design discussion here: https://support.1password.com/cs/agile-keychain-design/
 type AgileKeychain struct {
-	baseDir  string
-	contents keychainContents
-	encKeys  encryptionKeys
+	baseDir          string
+	pathBase         string
+	dateUnit         DateUnit
+	validationKDF    ValidationKDF
+	normalizer       func(string) string
+	titleNormalizer  func(string) string
+	keyring          KeyringProvider
+	useKeyring       bool
+	keyringService   string
+	keyringAccount   string
+	itemStages       []ItemStage
+	sharedRead       bool
+	snapshot         map[string][]byte
+	index            io.Reader
+	contents         keychainContents
+	urlIndex         map[string][]urlIndexEntry
+	encKeys          encryptionKeys
+	validationStatus map[string]error
+	decrypter        Decrypter
+	logger           Logger
+	vault            string
+	itemOpener       func(id string) (io.ReadCloser, error)
+	readOnly         bool
+	fsys             fs.FS
+	changelog        io.Writer
+	changelogMu      sync.Mutex
+	skipBadEntries   bool
+	skippedEntries   []error
+	keyMu            sync.Mutex
+	keyUse           sync.RWMutex
+	autoLockTimeout  time.Duration
+	autoLockTimer    *time.Timer
 }
 
+// Option configures an AgileKeychain at construction time
+type Option func(*AgileKeychain)
+
 // keychainContents is an array of keychainContentsEntrys
 type keychainContents []keychainContentsEntry
 
-// each entry is an array, but I'm not actually sure what the elements are
+// each entry is an array: id, type, title, site, date, the uuid of the folder
+// the item is in (empty if none), password strength and a trashed flag, at
+// indices 0 to 7.  The trashed flag is "Y" for trashed and deleted items, "N"
+// otherwise.  The password strength is the 0-100 rating 1Password computed
+// when the item was saved, with 0 meaning none was computed (1PasswordAnywhere
+// reads it as "passwordStrength").  Newer versions may append more elements;
+// they aren't interpreted, but are kept in extra for DebugContents.
 type keychainContentsEntry struct {
-	id        string
-	entryType string
-	title     string
-	site      string
-	date      int
-	unknown1  string
-	unknown2  int
-	unknown3  string
+	id               string
+	entryType        string
+	title            string
+	site             string
+	date             time.Time
+	folderID         string
+	passwordStrength int
+	trashed          bool
+	extra            []interface{}
 }
 
 type securityLevel int
@@ -47,16 +97,34 @@ const (
 	securityLevel5
 )
 
+func (l securityLevel) String() string {
+	switch l {
+	case securityLevel3:
+		return "SL3"
+	case securityLevel5:
+		return "SL5"
+	default:
+		return fmt.Sprintf("securityLevel(%d)", int(l))
+	}
+}
+
 type encryptionKey struct {
-	id    string
-	key   []byte
-	level securityLevel
+	id         string
+	key        []byte
+	level      securityLevel
+	iterations int
 }
 
 type encryptionKeys struct {
 	sl3  encryptionKey
 	sl5  encryptionKey
 	keys map[string]encryptionKey
+	// key ids in the order encryptionKeys.js lists them
+	ids []string
+	// an HMAC of the passphrase that unlocked the keys, keyed with the keys
+	// themselves, so that unlockWith can tell whether a passphrase is the
+	// same one without keeping it
+	passphraseMAC []byte
 }
 
 type rawEncryptionKey struct {
@@ -73,9 +141,50 @@ type rawEncryptionKeys struct {
 	List []rawEncryptionKey
 }
 
-// NewAgileKeychain creates a new AgileKeychain object, given a path
-// returns an error if path doesn't exist or is not a directory
-func NewAgileKeychain(keychainPath string) (*AgileKeychain, error) {
+// WithPassphraseNormalizer sets a function applied to the passphrase before
+// key derivation, in place of the default.
+//
+// The AgileKeychain format feeds the UTF-8 bytes of the passphrase straight
+// into PBKDF2, so "café" typed as a precomposed "é" (NFC) and as "e" plus a
+// combining accent (NFD) derive different keys.  By default the passphrase is
+// tried as typed and then in NFC and NFD, so a keychain created on a platform
+// that produces a different form than yours still opens.  A normalizer set
+// here is the only form tried.
+func WithPassphraseNormalizer(normalizer func(string) string) Option {
+	return func(k *AgileKeychain) {
+		k.normalizer = normalizer
+	}
+}
+
+// WithBaseDir makes NewAgileKeychain resolve a relative keychain path against
+// dir rather than the process's working directory, which may mean nothing in
+// a server.  dir itself may be relative, in which case it's resolved against
+// the working directory.
+func WithBaseDir(dir string) Option {
+	return func(k *AgileKeychain) {
+		k.pathBase = dir
+	}
+}
+
+// NewAgileKeychain creates a new AgileKeychain object, given a path and the
+// keychain's master passphrase, which is used to unlock its encryption keys.
+// The errors returned can be told apart with errors.Is: fs.ErrNotExist if
+// path doesn't exist, ErrNotADirectory if it isn't a directory,
+// ErrMalformedContents if contents.js can't be parsed, and
+// ErrWrongPassphrase, with ErrKeyValidationFailed when that's how it was
+// noticed, if the passphrase doesn't unlock the keys.  With
+// PassphraseFromKeyring, the passphrase argument is ignored.
+func NewAgileKeychain(keychainPath string, passphrase string, opts ...Option) (*AgileKeychain, error) {
+	ret := &AgileKeychain{}
+
+	for _, opt := range opts {
+		opt(ret)
+	}
+
+	if !path.IsAbs(keychainPath) {
+		keychainPath = path.Join(ret.pathBase, keychainPath)
+	}
+
 	if !path.IsAbs(keychainPath) {
 		dir, err := os.Getwd()
 		if err != nil {
@@ -84,26 +193,45 @@ func NewAgileKeychain(keychainPath string) (*AgileKeychain, error) {
 
 		keychainPath = path.Join(dir, keychainPath)
 	}
+	ret.baseDir = keychainPath
 
-	ret := &AgileKeychain{
-		baseDir: keychainPath,
+	if ret.vault != "" {
+		err := validateVaultName(ret.vault)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	fileinfo, err := os.Stat(keychainPath)
 	if os.IsNotExist(err) {
-		return nil, fmt.Errorf("Non-existent AgileKeychain path %s: %v", keychainPath, err)
+		return nil, fmt.Errorf("Non-existent AgileKeychain path %s: %w", keychainPath, err)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	if !fileinfo.IsDir() {
-		return nil, fmt.Errorf("AgileKeychain path %s not a directory", keychainPath)
+		return nil, fmt.Errorf("AgileKeychain path %s (%w)", keychainPath, ErrNotADirectory)
 	}
 
-	err = ret.loadContents()
+	if ret.sharedRead {
+		err = ret.takeSnapshot()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if ret.index != nil {
+		err = ret.LoadIndex(ret.index)
+		ret.index = nil
+	} else {
+		err = ret.loadContents()
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	err = ret.loadEncryptionKeys("1Password")
+	err = ret.unlock(passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -111,112 +239,601 @@ func NewAgileKeychain(keychainPath string) (*AgileKeychain, error) {
 	return ret, nil
 }
 
+// unlock a newly opened keychain whose contents are loaded: decrypt its keys
+// with passphrase, or the one from the keyring, and start the auto-lock timer
+func (k *AgileKeychain) unlock(passphrase string) error {
+	var err error
+	if k.useKeyring {
+		passphrase, err = k.keyringPassphrase()
+		if err != nil {
+			return err
+		}
+	}
+
+	return k.loadEncryptionKeys(passphrase)
+}
+
+// IsAgileKeychain reports whether keychainPath is laid out as an
+// AgileKeychain, with data/default/contents.js and encryptionKeys.js, so
+// that callers handed a vault of unknown format can tell it from an OPVault
+// (see opvault.IsOPVault) before opening it.  Only the layout is checked.
+func IsAgileKeychain(keychainPath string) bool {
+	for _, name := range []string{"contents.js", "encryptionKeys.js"} {
+		fileinfo, err := os.Stat(path.Join(keychainPath, "data", defaultVault, name))
+		if err != nil || !fileinfo.Mode().IsRegular() {
+			return false
+		}
+	}
+	return true
+}
+
+// Reload re-reads contents.js, picking up items that have been added,
+// removed or changed since the keychain was opened.  Encryption keys are left
+// as they are.  In shared read mode the snapshot is retaken.  A URL index
+// built by BuildURLIndex is rebuilt.  On error the previously loaded contents
+// are kept.
+func (k *AgileKeychain) Reload() error {
+	err := k.reloadContents()
+	if err != nil {
+		return err
+	}
+
+	if k.urlIndex != nil {
+		_, err = k.BuildURLIndex()
+	}
+	return err
+}
+
+func (k *AgileKeychain) reloadContents() error {
+	if !k.sharedRead {
+		return k.loadContents()
+	}
+
+	oldSnapshot := k.snapshot
+	err := k.takeSnapshot()
+	if err != nil {
+		return err
+	}
+
+	err = k.loadContents()
+	if err != nil {
+		k.snapshot = oldSnapshot
+	}
+	return err
+}
+
+// CanOpen reports whether passphrase unlocks the keychain at keychainPath.
+// Only the SL5 key is decrypted and validated, and contents.js isn't read, so
+// this is much cheaper than NewAgileKeychain.  As when opening, the
+// passphrase is also tried in NFC and NFD.  A wrong passphrase returns
+// false with a nil error; an error is only returned if the keychain couldn't
+// be read.
+func CanOpen(keychainPath string, passphrase string) (bool, error) {
+	k := &AgileKeychain{baseDir: keychainPath}
+
+	raw, err := k.readRawEncryptionKeys()
+	if err != nil {
+		return false, err
+	}
+
+	for _, rawKey := range raw.List {
+		if rawKey.Identifier != raw.SL5 {
+			continue
+		}
+
+		blob, err := decodeBase64(rawKey.Data)
+		if err != nil {
+			return false, err
+		}
+
+		validationBytes, err := decodeBase64(rawKey.Validation)
+		if err != nil {
+			return false, err
+		}
+
+		for _, candidate := range passphraseForms(passphrase) {
+			key, err := decryptKey(blob, rawKey.Iterations, candidate)
+			if err != nil || key == nil {
+				continue
+			}
+			if validateKey(key, validationBytes, rawKey.Iterations, ValidationKDFAuto) == nil {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	return false, fmt.Errorf("Couldn't find SL5 key with id %s", raw.SL5)
+}
+
 // load contents.js into contents
 func (k *AgileKeychain) loadContents() error {
-	contentsPath := path.Join(k.baseDir, "data", "default", "contents.js")
-	f, err := os.Open(contentsPath)
+	contentsPath := path.Join(k.dataDir(), "contents.js")
+	data, err := k.readFile(contentsPath)
 	if err != nil {
 		return err
 	}
 
+	contents, skipped, err := k.parseContents(data)
+	if err != nil {
+		return err
+	}
+
+	k.contents = contents
+	k.skippedEntries = skipped
+	return nil
+}
+
+// ParseContents parses the bytes of a contents.js file, returning its
+// entries in order.  This is the parser that opening a keychain uses, and it
+// honors the same options, such as WithDateUnit; options that don't affect
+// parsing are ignored.  With WithSkipBadEntries, the entries that parsed are
+// returned along with an error joining those of the entries that didn't.
+func ParseContents(data []byte, opts ...Option) ([]Item, error) {
+	k := &AgileKeychain{}
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	contents, skipped, err := k.parseContents(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]Item, len(contents))
+	for ix, entry := range contents {
+		ret[ix] = entry.item()
+	}
+	return ret, errors.Join(skipped...)
+}
+
+// parse contents.js.  Unless the keychain skips bad entries, the first entry
+// that doesn't parse fails the lot; otherwise such entries are left out and
+// their errors returned in skipped.
+func (k *AgileKeychain) parseContents(data []byte) (contents keychainContents, skipped []error, err error) {
 	type rawKeychainEntry []interface{}
 	type rawKeychainContents []rawKeychainEntry
 	var rawContents rawKeychainContents
 
-	err = json.NewDecoder(f).Decode(&rawContents)
+	// decode numbers as json.Number so that timestamps aren't rounded
+	// through float64
+	err = unmarshalUseNumber(data, &rawContents)
 	if err != nil {
-		return err
+		relaxedErr := unmarshalUseNumber(relaxJSON(data), &rawContents)
+		if relaxedErr != nil {
+			return nil, nil, fmt.Errorf("Failed to parse contents.js (%w): %w", ErrMalformedContents, err)
+		}
 	}
 
-	cookedContents := make([]keychainContentsEntry, len(rawContents))
+	contents = make([]keychainContentsEntry, 0, len(rawContents))
 
 	for ix, entry := range rawContents {
-		var e keychainContentsEntry
-		var ok bool
-		var tmp float64
+		cooked, err := parseContentsEntry(entry, k.dateUnit)
+		if err != nil {
+			if !k.skipBadEntries {
+				return nil, nil, err
+			}
+			skipped = append(skipped, fmt.Errorf("contents.js entry %d: %w", ix, err))
+			continue
+		}
+		contents = append(contents, cooked)
+	}
+
+	return contents, skipped, nil
+}
+
+// WithSkipBadEntries makes the keychain leave out contents.js entries that
+// don't parse, rather than failing to open, so that one corrupt entry doesn't
+// make the rest of the keychain unusable.  SkippedEntries reports what was
+// left out.  Without this option, or with it false, the first bad entry is an
+// error, as it always has been.  A contents.js that isn't a JSON array fails
+// either way.
+func WithSkipBadEntries(skip bool) Option {
+	return func(k *AgileKeychain) {
+		k.skipBadEntries = skip
+	}
+}
 
-		allOk := true
+// SkippedEntries returns an error for each contents.js entry that was left out
+// the last time contents.js was loaded, as WithSkipBadEntries allows, saying
+// where the entry was and what was wrong with it.  Empty if nothing was
+// skipped.
+func (k *AgileKeychain) SkippedEntries() []error {
+	return append([]error{}, k.skippedEntries...)
+}
 
-		e.id, ok = entry[0].(string)
-		allOk = allOk && ok
+// like json.Unmarshal, but decoding numbers in interface{} values as
+// json.Number rather than float64
+func unmarshalUseNumber(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
 
-		e.entryType, ok = entry[1].(string)
-		allOk = allOk && ok
+	err := decoder.Decode(v)
+	if err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
 
-		e.title, ok = entry[2].(string)
-		allOk = allOk && ok
+// ParseContentsEntry parses one entry of contents.js, given as the array
+// encoding/json decodes it to, using the same rules as opening a keychain
+// does with the default DateUnitAuto.  Numbers may be float64s or, to keep
+// large timestamps exact, json.Numbers as from a Decoder with UseNumber.
+func ParseContentsEntry(raw []interface{}) (Item, error) {
+	e, err := parseContentsEntry(raw, DateUnitAuto)
+	if err != nil {
+		return Item{}, err
+	}
+	return e.item(), nil
+}
+
+// parse one contents.js entry.  The id and type are required; any other
+// element may be null, which is read as its zero value.
+func parseContentsEntry(entry []interface{}, unit DateUnit) (keychainContentsEntry, error) {
+	var e keychainContentsEntry
+
+	if len(entry) < 8 {
+		return e, fmt.Errorf("Failed to parse keychain contents entry (%w), %d elements instead of 8: %#v", ErrMalformedContents, len(entry), entry)
+	}
+
+	var ok bool
+	var tmp int64
 
-		e.site, ok = entry[3].(string)
-		allOk = allOk && ok
+	allOk := true
 
-		tmp, ok = entry[4].(float64)
-		e.date = int(tmp)
-		allOk = allOk && ok
+	e.id, ok = entry[0].(string)
+	allOk = allOk && ok
 
-		e.unknown1, ok = entry[5].(string)
-		allOk = allOk && ok
+	e.entryType, ok = entry[1].(string)
+	allOk = allOk && ok
 
-		tmp, ok = entry[6].(float64)
-		e.unknown2 = int(tmp)
-		allOk = allOk && ok
+	e.title, ok = optionalString(entry[2])
+	allOk = allOk && ok
 
-		e.unknown3, ok = entry[7].(string)
-		allOk = allOk && ok
+	e.site, ok = optionalString(entry[3])
+	allOk = allOk && ok
 
-		if !allOk {
-			return fmt.Errorf("Failed to parse keychain contents entry: %#v", entry)
+	tmp, ok = optionalNumber(entry[4])
+	if entry[4] != nil {
+		e.date = parseDate(tmp, unit)
+	}
+	allOk = allOk && ok
+
+	e.folderID, ok = optionalString(entry[5])
+	allOk = allOk && ok
+
+	tmp, ok = optionalNumber(entry[6])
+	e.passwordStrength = int(tmp)
+	allOk = allOk && ok
+
+	trashed, ok := optionalString(entry[7])
+	e.trashed = trashed == "Y"
+	allOk = allOk && ok
+
+	if len(entry) > 8 {
+		e.extra = append([]interface{}{}, entry[8:]...)
+	}
+
+	if !allOk {
+		return e, fmt.Errorf("Failed to parse keychain contents entry (%w): %#v", ErrMalformedContents, entry)
+	}
+	return e, nil
+}
+
+// DebugContents maps the id of each item whose contents.js entry has elements
+// past the eight this package reads to those elements, as encoding/json
+// decodes them with numbers as json.Number, to help work out what newer
+// versions of 1Password store there.  Items without extra elements are left
+// out.
+func (k *AgileKeychain) DebugContents() map[string][]interface{} {
+	ret := make(map[string][]interface{})
+	for _, entry := range k.contents {
+		if len(entry.extra) > 0 {
+			ret[entry.id] = append([]interface{}{}, entry.extra...)
 		}
-		cookedContents[ix] = e
 	}
+	return ret
+}
 
-	k.contents = cookedContents
-	return nil
+// a JSON string or null
+func optionalString(value interface{}) (string, bool) {
+	if value == nil {
+		return "", true
+	}
+	s, ok := value.(string)
+	return s, ok
+}
+
+// a JSON number or null, as an integer.  Numbers decoded as json.Number
+// are converted exactly; float64s, as encoding/json decodes numbers by
+// default, have already lost precision past 2^53.  Numbers outside the
+// range of an int64 aren't valid.
+func optionalNumber(value interface{}) (int64, bool) {
+	switch n := value.(type) {
+	case nil:
+		return 0, true
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return i, true
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return floatToInt64(f)
+	case float64:
+		return floatToInt64(n)
+	default:
+		return 0, false
+	}
+}
+
+// truncate f to an int64, if it's in range
+func floatToInt64(f float64) (int64, bool) {
+	// -2^63 is exactly representable and in range; 2^63 is out of range
+	if !(f >= math.MinInt64 && f < -math.MinInt64) {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// read a file, from the snapshot in shared read mode, the fs.FS given to
+// NewAgileKeychainFS or the readers given to NewAgileKeychainFromReaders, or
+// else from disk
+func (k *AgileKeychain) readFile(filePath string) ([]byte, error) {
+	if k.fsys != nil {
+		return fs.ReadFile(k.fsys, filePath)
+	}
+
+	if k.itemOpener != nil {
+		return k.readStreamedFile(filePath)
+	}
+
+	if k.sharedRead {
+		data, ok := k.snapshot[filePath]
+		if !ok {
+			return nil, &os.PathError{Op: "open", Path: filePath, Err: os.ErrNotExist}
+		}
+		return data, nil
+	}
+
+	return ioutil.ReadFile(filePath)
+}
+
+// read and parse encryptionKeys.js, without decrypting anything
+func (k *AgileKeychain) readRawEncryptionKeys() (rawEncryptionKeys, error) {
+	var raw rawEncryptionKeys
+
+	contentsPath := path.Join(k.dataDir(), "encryptionKeys.js")
+	data, err := k.readFile(contentsPath)
+	if err != nil {
+		return raw, err
+	}
+
+	// try strict JSON first, then fall back to treating it as javascript
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		relaxedErr := json.Unmarshal(relaxJSON(data), &raw)
+		if relaxedErr != nil {
+			return raw, err
+		}
+	}
+
+	return raw, nil
 }
 
 func (k *AgileKeychain) loadEncryptionKeys(passphrase string) error {
-	contentsPath := path.Join(k.baseDir, "data", "default", "encryptionKeys.js")
-	f, err := os.Open(contentsPath)
+	raw, err := k.readRawEncryptionKeys()
 	if err != nil {
 		return err
 	}
 
-	var raw rawEncryptionKeys
+	k.logf("Found %d keys", len(raw.List))
 
-	err = json.NewDecoder(f).Decode(&raw)
+	var encKeys encryptionKeys
+	for _, candidate := range k.passphraseCandidates(passphrase) {
+		encKeys, err = k.decryptKeys(raw, candidate)
+		if !errors.Is(err, ErrWrongPassphrase) {
+			break
+		}
+	}
 	if err != nil {
 		return err
 	}
 
-	k.encKeys.keys = make(map[string]encryptionKey, len(raw.List))
+	encKeys.passphraseMAC = encKeys.passphraseMACOf(passphrase)
+
+	// wait for decryptions using the old keys before wiping them
+	k.keyUse.Lock()
+	k.keyMu.Lock()
+	zeroKeys(k.encKeys)
+	k.encKeys = encKeys
+	k.keyMu.Unlock()
+	k.keyUse.Unlock()
+
+	// keys loaded after an auto-lock or Close need the timer again
+	k.startAutoLock()
+	return nil
+}
+
+// make sure the keys that passphrase unlocks are loaded, only deriving them
+// again if the keychain is locked or was unlocked with another passphrase
+func (k *AgileKeychain) unlockWith(passphrase string) error {
+	k.keyMu.Lock()
+	keys := k.encKeys
+	same := keys.keys != nil && hmac.Equal(keys.passphraseMAC, keys.passphraseMACOf(passphrase))
+	k.keyMu.Unlock()
+
+	if same {
+		return nil
+	}
+	return k.loadEncryptionKeys(passphrase)
+}
+
+func (keys encryptionKeys) passphraseMACOf(passphrase string) []byte {
+	var macKey []byte
+	for _, id := range keys.ids {
+		macKey = append(macKey, keys.keys[id].key...)
+	}
+	defer clear(macKey)
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write([]byte(passphrase))
+	return mac.Sum(nil)
+}
+
+// the forms of passphrase to try in turn: the WithPassphraseNormalizer form if
+// one is set, otherwise passphraseForms
+func (k *AgileKeychain) passphraseCandidates(passphrase string) []string {
+	if k.normalizer != nil {
+		return []string{k.normalizer(passphrase)}
+	}
+	return passphraseForms(passphrase)
+}
+
+// passphrase as typed, then its NFC and NFD forms where they differ
+func passphraseForms(passphrase string) []string {
+	forms := []string{passphrase}
+	for _, form := range []string{norm.NFC.String(passphrase), norm.NFD.String(passphrase)} {
+		if !slices.Contains(forms, form) {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
+
+// decrypt and validate the keys in raw with passphrase.  ValidationStatus is
+// updated either way.
+func (k *AgileKeychain) decryptKeys(raw rawEncryptionKeys, passphrase string) (encryptionKeys, error) {
+	// build the keys up separately so that a wrong passphrase leaves any
+	// previously loaded keys intact
+	var encKeys encryptionKeys
+	var err error
+	encKeys.keys = make(map[string]encryptionKey, len(raw.List))
+
+	// keep going past a bad key so that ValidationStatus can report on
+	// every level
+	status := map[string]error{
+		"SL3": fmt.Errorf("Couldn't find SL3 key with id %s", raw.SL3),
+		"SL5": fmt.Errorf("Couldn't find SL5 key with id %s", raw.SL5),
+	}
+	var firstErr error
 
 	for _, rawKey := range raw.List {
-		key, err := parseRawEncryptionKey(rawKey, passphrase)
+		var key encryptionKey
+		if k.decrypter != nil {
+			// the Decrypter has the keys; just note which there are
+			key, err = parseRawKeyMetadata(rawKey)
+		} else {
+			key, err = parseRawEncryptionKey(rawKey, passphrase, k.validationKDF)
+		}
+		switch rawKey.Identifier {
+		case raw.SL3:
+			status["SL3"] = err
+		case raw.SL5:
+			status["SL5"] = err
+		}
 		if err != nil {
-			return err
+			k.logf("key %s (%s) failed: %v", rawKey.Identifier, rawKey.Level, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
 		}
+		if k.decrypter == nil {
+			k.logf("validated key %s (%s)", key.id, key.level)
+		}
+
+		encKeys.keys[key.id] = key
+		encKeys.ids = append(encKeys.ids, key.id)
+	}
 
-		k.encKeys.keys[key.id] = key
+	k.validationStatus = status
+	if firstErr != nil {
+		zeroKeys(encKeys)
+		return encryptionKeys{}, firstErr
 	}
 
 	var ok bool
 
-	k.encKeys.sl3, ok = k.encKeys.keys[raw.SL3]
+	encKeys.sl3, ok = encKeys.keys[raw.SL3]
 	if !ok {
-		return fmt.Errorf("Couldn't find SL3 key with id %s", raw.SL3)
+		zeroKeys(encKeys)
+		return encryptionKeys{}, fmt.Errorf("Couldn't find SL3 key with id %s", raw.SL3)
 	}
 
-	k.encKeys.sl5, ok = k.encKeys.keys[raw.SL5]
+	encKeys.sl5, ok = encKeys.keys[raw.SL5]
 	if !ok {
-		return fmt.Errorf("Couldn't find SL5 key with id %s", raw.SL5)
+		zeroKeys(encKeys)
+		return encryptionKeys{}, fmt.Errorf("Couldn't find SL5 key with id %s", raw.SL5)
 	}
 
-	return nil
+	return encKeys, nil
+}
+
+// SecurityLevels lists, in order, the security levels ("SL3", "SL5") that
+// have a decrypted key loaded, and so whose items can be decrypted.  It is
+// empty until a passphrase has been accepted, and again after Close.
+func (k *AgileKeychain) SecurityLevels() []string {
+	k.keyMu.Lock()
+	defer k.keyMu.Unlock()
+
+	seen := make(map[securityLevel]bool)
+	for _, key := range k.encKeys.keys {
+		seen[key.level] = true
+	}
+
+	var ret []string
+	for _, level := range []securityLevel{securityLevel3, securityLevel5} {
+		if seen[level] {
+			ret = append(ret, level.String())
+		}
+	}
+	return ret
+}
+
+// KeyIdentifiers lists the identifiers of the loaded encryption keys in the
+// order encryptionKeys.js lists them.  Identifiers aren't secret, and with
+// KeyForItem they show which key an item that won't decrypt is looking for.
+// Empty if no keys are loaded.
+func (k *AgileKeychain) KeyIdentifiers() []string {
+	k.keyMu.Lock()
+	defer k.keyMu.Unlock()
+
+	return append([]string{}, k.encKeys.ids...)
 }
 
-func parseRawEncryptionKey(raw rawEncryptionKey, passphrase string) (encryptionKey, error) {
+// SameMasterKey reports whether other has the same SL3 and SL5 master keys
+// as this keychain, as copies of one keychain do, so that keys already
+// decrypted for one can be used for the other.  The keys are compared in
+// constant time.  Both keychains must be unlocked.
+func (k *AgileKeychain) SameMasterKey(other *AgileKeychain) (bool, error) {
+	mine, err := k.keys()
+	if err != nil {
+		return false, err
+	}
+	theirs, err := other.keys()
+	if err != nil {
+		return false, err
+	}
+	if mine.sl3.key == nil || theirs.sl3.key == nil {
+		return false, errors.New("Master keys aren't in memory with WithDecrypter")
+	}
+
+	same := subtle.ConstantTimeCompare(mine.sl3.key, theirs.sl3.key) &
+		subtle.ConstantTimeCompare(mine.sl5.key, theirs.sl5.key)
+	return same == 1, nil
+}
+
+// the id, level and iterations of a key, without decrypting it
+func parseRawKeyMetadata(raw rawEncryptionKey) (encryptionKey, error) {
 	var ret encryptionKey
 
 	ret.id = raw.Identifier
+	ret.iterations = raw.Iterations
 	switch raw.Level {
 	case "SL3":
 		ret.level = securityLevel3
@@ -226,30 +843,49 @@ func parseRawEncryptionKey(raw rawEncryptionKey, passphrase string) (encryptionK
 		return ret, fmt.Errorf("Unknown security level %s", raw.Level)
 	}
 
-	blob, err := base64.StdEncoding.DecodeString(stripTrailingNull(raw.Data))
+	return ret, nil
+}
+
+func parseRawEncryptionKey(raw rawEncryptionKey, passphrase string, kdf ValidationKDF) (encryptionKey, error) {
+	ret, err := parseRawKeyMetadata(raw)
+	if err != nil {
+		return ret, err
+	}
+
+	blob, err := decodeBase64(raw.Data)
 	if err != nil {
 		return ret, err
 	}
 
-	validationBytes, err := base64.StdEncoding.DecodeString(stripTrailingNull(raw.Validation))
+	validationBytes, err := decodeBase64(raw.Validation)
 	if err != nil {
 		return ret, err
 	}
 
 	ret.key, err = decryptKey(blob, raw.Iterations, passphrase)
-	err = validateKey(ret.key, validationBytes)
 	if err != nil {
-		return ret, fmt.Errorf("Failed to validate key %s: %v", ret.id, err)
+		// with the wrong passphrase the padding is usually garbage
+		return ret, fmt.Errorf("Failed to decrypt key %s (%w): %v", ret.id, ErrWrongPassphrase, err)
+	}
+
+	err = validateKey(ret.key, validationBytes, raw.Iterations, kdf)
+	if err != nil {
+		return ret, fmt.Errorf("Failed to validate key %s (%w): %w", ret.id, ErrWrongPassphrase, err)
 	}
 
 	return ret, nil
 }
 
+// strip the NUL terminators 1Password writes after some strings, of which
+// there may be more than one
 func stripTrailingNull(str string) string {
-	if strings.HasSuffix(str, "\u0000") {
-		return str[0 : len(str)-len("\u0000")]
-	}
-	return str
+	return strings.TrimRight(str, "\u0000")
+}
+
+// decode a base64 field from a keychain file, tolerating trailing NULs and
+// missing padding
+func decodeBase64(str string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(stripTrailingNull(str), "="))
 }
 
 func decryptKey(dataBytes []byte, iterations int, passphrase string) ([]byte, error) {
@@ -265,28 +901,7 @@ func decryptKey(dataBytes []byte, iterations int, passphrase string) ([]byte, er
 	// and associated IV
 	iv := derivedKey[16:32]
 
-	key, err := cbcDecrypt(blob, kek, iv)
-
-	return key, nil
-}
-
-func validateKey(keyBytes []byte, validationBytes []byte) error {
-	salt, blob, err := extractSalt(validationBytes)
-	if err != nil {
-		return err
-	}
-
-	kek, iv := deriveOpensslKey(keyBytes, salt)
-
-	validationResult, err := cbcDecrypt(blob, kek, iv)
-	if err != nil {
-		return err
-	}
-
-	if !bytes.Equal(keyBytes, validationResult) {
-		return errors.New("key validation failed")
-	}
-	return nil
+	return cbcDecrypt(blob, kek, iv)
 }
 
 func cbcDecrypt(blob []byte, key []byte, iv []byte) (output []byte, err error) {
@@ -300,6 +915,11 @@ func cbcDecrypt(blob []byte, key []byte, iv []byte) (output []byte, err error) {
 		return nil, err
 	}
 
+	// CryptBlocks panics on partial blocks
+	if len(blob) == 0 || len(blob)%decrypter.BlockSize() != 0 {
+		return nil, errors.New("Ciphertext is not a multiple of the block size")
+	}
+
 	ret := make([]byte, len(blob))
 	decrypter.CryptBlocks(ret, blob)
 
@@ -311,6 +931,27 @@ func cbcDecrypt(blob []byte, key []byte, iv []byte) (output []byte, err error) {
 	return ret, nil
 }
 
+func cbcEncrypt(plaintext []byte, key []byte, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypter := cipher.NewCBCEncrypter(block, iv)
+
+	padded := pad(plaintext, encrypter.BlockSize())
+	ret := make([]byte, len(padded))
+	encrypter.CryptBlocks(ret, padded)
+
+	return ret, nil
+}
+
+// add pkcs7 padding
+func pad(data []byte, blocksize int) []byte {
+	padSize := blocksize - len(data)%blocksize
+	return append(append([]byte{}, data...), bytes.Repeat([]byte{byte(padSize)}, padSize)...)
+}
+
 // remove pkcs7 padding
 func unpad(data []byte, blocksize int) ([]byte, error) {
 	if blocksize <= 0 {
@@ -346,6 +987,11 @@ func unpad(data []byte, blocksize int) ([]byte, error) {
 
 // OpenSSL has a particular way of storing a salt alongside a blob
 func extractSalt(input []byte) (salt []byte, blob []byte, err error) {
+	// the 8-byte magic and 8-byte salt
+	if len(input) < 16 {
+		return nil, nil, fmt.Errorf("OpenSSL salted data too short: %d bytes, want at least 16", len(input))
+	}
+
 	// if the data starts with "Salted__", then the first 8 bytes following that are the salt
 	if bytes.Equal(input[0:8], []byte(`Salted__`)) {
 		return input[8:16], input[16:], nil
@@ -358,24 +1004,56 @@ func extractSalt(input []byte) (salt []byte, blob []byte, err error) {
 	}
 }
 
-// OpenSSL also has a particular/odd key derivation function
-func deriveOpensslKey(password []byte, salt []byte) (key []byte, iv []byte) {
-	rounds := 2
-	data := append(password, salt...)
-	md5Hashes := make([][]byte, rounds)
-	sum := md5.Sum(data)
-
-	md5Hashes[0] = append([]byte{}, sum[:]...)
-
-	for i := 1; i < rounds; i++ {
-		sum = md5.Sum(append(md5Hashes[i-1], data...))
-		md5Hashes[i] = append([]byte{}, sum[:]...)
+// OpenSSL also has a particular/odd key derivation function, EVP_BytesToKey
+// with MD5 and one iteration: MD5 hashes of password and salt, each after the
+// first prefixed with the one before, are concatenated until there are enough
+// bytes for the key followed by the IV
+func deriveOpensslKey(password []byte, salt []byte, keyLen int, ivLen int) (key []byte, iv []byte) {
+	data := make([]byte, 0, len(password)+len(salt))
+	data = append(append(data, password...), salt...)
+
+	var derived, prev []byte
+	for len(derived) < keyLen+ivLen {
+		sum := md5.Sum(append(prev, data...))
+		prev = sum[:]
+		derived = append(derived, prev...)
 	}
 
-	return md5Hashes[0], md5Hashes[1]
+	return derived[:keyLen], derived[keyLen : keyLen+ivLen]
 }
 
 // Length of the keychain
 func (k *AgileKeychain) Length() int {
 	return len(k.contents)
 }
+
+// files in the vault directory that RawFile will return
+var rawFiles = map[string]bool{
+	"contents.js":       true,
+	"encryptionKeys.js": true,
+}
+
+// RawFile returns the unparsed bytes of one of the keychain's index files,
+// contents.js or encryptionKeys.js, from the snapshot in shared read mode.
+// Any other name is rejected, so this can't be used to read arbitrary paths.
+func (k *AgileKeychain) RawFile(name string) ([]byte, error) {
+	if !rawFiles[name] {
+		return nil, fmt.Errorf("Not a known keychain file: %q", name)
+	}
+
+	data, err := k.readFile(path.Join(k.dataDir(), name))
+	if err != nil {
+		return nil, err
+	}
+	// don't hand out the snapshot's own copy
+	return bytes.Clone(data), nil
+}
+
+// ExpectItemCount returns an error if the keychain doesn't have exactly n
+// entries, as counted by Length
+func (k *AgileKeychain) ExpectItemCount(n int) error {
+	if length := k.Length(); length != n {
+		return fmt.Errorf("Keychain has %d items, expected %d", length, n)
+	}
+	return nil
+}
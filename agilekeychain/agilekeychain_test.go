@@ -1,12 +1,26 @@
 package agilekeychain
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
 )
 
+const example1Path = "../testdata/agilekeychain/example1/1Password.agilekeychain"
+
 func TestNewAgileKeychain_Errors(t *testing.T) {
 	type args struct {
 		path string
@@ -32,7 +46,7 @@ func TestNewAgileKeychain_Errors(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewAgileKeychain(tt.args.path)
+			got, err := NewAgileKeychain(tt.args.path, "1Password")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewAgileKeychain() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -48,7 +62,7 @@ func TestNewAgileKeychain_Example1(t *testing.T) {
 	// this fixture shamelessly copied from https://github.com/alsemyonov/one_password
 	fixturePath := "../testdata/agilekeychain/example1/1Password.agilekeychain"
 
-	keychain1, err := NewAgileKeychain(fixturePath)
+	keychain1, err := NewAgileKeychain(fixturePath, "1Password")
 	if err != nil {
 		t.Errorf("Error creating agilekeychain from fixture with relative path: %v", err)
 	}
@@ -59,7 +73,7 @@ func TestNewAgileKeychain_Example1(t *testing.T) {
 	}
 
 	absPath := path.Join(cwd, fixturePath)
-	keychain2, err := NewAgileKeychain(absPath)
+	keychain2, err := NewAgileKeychain(absPath, "1Password")
 	if err != nil {
 		t.Errorf("Error creating agilekeychain from fixture with absolute path: %v", err)
 	}
@@ -72,4 +86,698 @@ func TestNewAgileKeychain_Example1(t *testing.T) {
 	if length != 19 {
 		t.Errorf("Got wrong size: %d", length)
 	}
+
+	err = keychain1.ExpectItemCount(19)
+	if err != nil {
+		t.Errorf("ExpectItemCount(19) error = %v", err)
+	}
+
+	err = keychain1.ExpectItemCount(20)
+	if err == nil || !strings.Contains(err.Error(), "19 items, expected 20") {
+		t.Errorf("ExpectItemCount(20) error = %v", err)
+	}
+}
+
+func TestLoadEncryptionKeys_UnicodePassphrase(t *testing.T) {
+	// the fixture's passphrase is "café" with a precomposed é (NFC)
+	fixturePath := "../testdata/agilekeychain/unicode/1Password.agilekeychain"
+	nfc := norm.NFC.String("café")
+	nfd := norm.NFD.String("café")
+	if nfc == nfd {
+		t.Fatalf("NFC and NFD forms of the passphrase are the same")
+	}
+
+	for _, passphrase := range []string{nfc, nfd} {
+		keychain := &AgileKeychain{baseDir: fixturePath}
+		err := keychain.loadEncryptionKeys(passphrase)
+		if err != nil {
+			t.Errorf("Failed to load keys with passphrase %+q: %v", passphrase, err)
+		}
+
+		ok, err := CanOpen(fixturePath, passphrase)
+		if err != nil || !ok {
+			t.Errorf("CanOpen(%+q) = %v, %v, want true", passphrase, ok, err)
+		}
+	}
+
+	// a normalizer replaces the default forms
+	keychain := &AgileKeychain{baseDir: fixturePath}
+	WithPassphraseNormalizer(norm.NFD.String)(keychain)
+	err := keychain.loadEncryptionKeys(nfc)
+	if !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("loadEncryptionKeys() with an NFD normalizer error = %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestRawFile(t *testing.T) {
+	fixturePath := "../testdata/agilekeychain/example1/1Password.agilekeychain"
+
+	keychain, err := NewAgileKeychain(fixturePath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	for _, name := range []string{"contents.js", "encryptionKeys.js"} {
+		want, err := ioutil.ReadFile(path.Join(fixturePath, "data", "default", name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+
+		got, err := keychain.RawFile(name)
+		if err != nil {
+			t.Errorf("RawFile(%q) error = %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("RawFile(%q) returned wrong contents", name)
+		}
+	}
+
+	for _, name := range []string{"", "1password.keys", "../../config/buildnum", "/etc/passwd", "contents.js/../encryptionKeys.js"} {
+		_, err := keychain.RawFile(name)
+		if err == nil {
+			t.Errorf("RawFile(%q) did not fail", name)
+		}
+	}
+}
+
+func TestCanOpen(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		passphrase string
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name:       "Right passphrase",
+			path:       "../testdata/agilekeychain/example1/1Password.agilekeychain",
+			passphrase: "1Password",
+			want:       true,
+		},
+		{
+			name:       "Wrong passphrase",
+			path:       "../testdata/agilekeychain/example1/1Password.agilekeychain",
+			passphrase: "wrong passphrase",
+			want:       false,
+		},
+		{
+			name:       "Non-ASCII passphrase",
+			path:       "../testdata/agilekeychain/unicode/1Password.agilekeychain",
+			passphrase: "caf\u00e9",
+			want:       true,
+		},
+		{
+			name:       "Nonexistent keychain",
+			path:       "/nonexist4329489erjgar",
+			passphrase: "1Password",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanOpen(tt.path, tt.passphrase)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CanOpen() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CanOpen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseContentsEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    Item
+		wantErr bool
+	}{
+		{
+			name: "Full entry",
+			raw:  `["13C8E12AC8E54B1F873BAB0824E521BC","webforms.WebForm","Hulu","hulu.com",1362350139,"",42,"N"]`,
+			want: Item{ID: "13C8E12AC8E54B1F873BAB0824E521BC", Type: "webforms.WebForm", Title: "Hulu", Site: "hulu.com", Date: time.Unix(1362350139, 0), PasswordStrength: 42},
+		},
+		{
+			name: "Trashed entry",
+			raw:  `["13C8E12AC8E54B1F873BAB0824E521BC","webforms.WebForm","Hulu","hulu.com",1362350139,"",42,"Y"]`,
+			want: Item{ID: "13C8E12AC8E54B1F873BAB0824E521BC", Type: "webforms.WebForm", Title: "Hulu", Site: "hulu.com", Date: time.Unix(1362350139, 0), PasswordStrength: 42, Trashed: true},
+		},
+		{
+			name: "Nulls read as zero values",
+			raw:  `["13C8E12AC8E54B1F873BAB0824E521BC","webforms.WebForm",null,null,null,null,null,null]`,
+			want: Item{ID: "13C8E12AC8E54B1F873BAB0824E521BC", Type: "webforms.WebForm"},
+		},
+		{
+			name: "Extra elements ignored",
+			raw:  `["13C8E12AC8E54B1F873BAB0824E521BC","webforms.WebForm","Hulu","hulu.com",1362350139,"",42,"N",{"favorite":true},"more"]`,
+			want: Item{ID: "13C8E12AC8E54B1F873BAB0824E521BC", Type: "webforms.WebForm", Title: "Hulu", Site: "hulu.com", Date: time.Unix(1362350139, 0), PasswordStrength: 42},
+		},
+		{
+			name:    "Too short",
+			raw:     `["13C8E12AC8E54B1F873BAB0824E521BC","webforms.WebForm","Hulu"]`,
+			wantErr: true,
+		},
+		{
+			name:    "Empty",
+			raw:     `[]`,
+			wantErr: true,
+		},
+		{
+			name:    "Null id",
+			raw:     `[null,"webforms.WebForm","Hulu","hulu.com",1362350139,"",0,"N"]`,
+			wantErr: true,
+		},
+		{
+			name:    "Wrong type",
+			raw:     `["13C8E12AC8E54B1F873BAB0824E521BC","webforms.WebForm","Hulu","hulu.com","yesterday","",0,"N"]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var raw []interface{}
+			err := json.Unmarshal([]byte(tt.raw), &raw)
+			if err != nil {
+				t.Fatalf("Bad test entry: %v", err)
+			}
+
+			got, err := ParseContentsEntry(raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseContentsEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseContentsEntry() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAgileKeychain_Passphrase(t *testing.T) {
+	fixturePath := "../testdata/agilekeychain/unicode/1Password.agilekeychain"
+
+	keychain, err := NewAgileKeychain(fixturePath, "caf\u00e9")
+	if err != nil {
+		t.Fatalf("NewAgileKeychain() with the right passphrase error = %v", err)
+	}
+	if notes, err := keychain.DecryptItem("C0FFEE00C0FFEE00C0FFEE00C0FFEE01"); err != nil {
+		t.Errorf("DecryptItem() = %v, %v", notes, err)
+	}
+
+	for _, passphrase := range []string{"1Password", "", "cafe"} {
+		_, err = NewAgileKeychain(fixturePath, passphrase)
+		if !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("NewAgileKeychain(%q) error = %v, want ErrWrongPassphrase", passphrase, err)
+		}
+	}
+}
+
+func TestParseContents(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		opts    []Option
+		want    []Item
+		wantErr bool
+	}{
+		{
+			name: "Empty",
+			data: `[]`,
+			want: []Item{},
+		},
+		{
+			name: "Nulls",
+			data: `[["A","webforms.WebForm",null,null,null,null,null,null]]`,
+			want: []Item{{ID: "A", Type: "webforms.WebForm"}},
+		},
+		{
+			name: "Duplicate ids are kept",
+			data: `[["A","webforms.WebForm","One","",0,"",0,"N"],["A","webforms.WebForm","Two","",0,"",0,"N"]]`,
+			want: []Item{
+				{ID: "A", Type: "webforms.WebForm", Title: "One", Date: time.Unix(0, 0)},
+				{ID: "A", Type: "webforms.WebForm", Title: "Two", Date: time.Unix(0, 0)},
+			},
+		},
+		{
+			name: "Date unit option",
+			data: `[["A","webforms.WebForm","One","",1362350139,"",0,"N"]]`,
+			opts: []Option{WithDateUnit(DateUnitMilliseconds)},
+			want: []Item{{ID: "A", Type: "webforms.WebForm", Title: "One", Date: time.Unix(1362350, 139*int64(time.Millisecond))}},
+		},
+		{
+			name:    "String date",
+			data:    `[["A","webforms.WebForm","One","","2013-03-03","",0,"N"]]`,
+			wantErr: true,
+		},
+		{
+			name:    "Short entry",
+			data:    `[["A","webforms.WebForm"]]`,
+			wantErr: true,
+		},
+		{
+			name:    "Not JSON",
+			data:    `[["A",`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseContents([]byte(tt.data), tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseContents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseContents() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecurityLevels(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	want := []string{"SL3", "SL5"}
+	if got := keychain.SecurityLevels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SecurityLevels() = %v, want %v", got, want)
+	}
+
+	var locked AgileKeychain
+	if got := locked.SecurityLevels(); len(got) != 0 {
+		t.Errorf("SecurityLevels() with no keys loaded = %v, want none", got)
+	}
+}
+
+func TestDecryptKey_Corrupted(t *testing.T) {
+	keychain := &AgileKeychain{baseDir: example1Path}
+	raw, err := keychain.readRawEncryptionKeys()
+	if err != nil {
+		t.Fatalf("readRawEncryptionKeys() error = %v", err)
+	}
+	blob, err := decodeBase64(raw.List[0].Data)
+	if err != nil {
+		t.Fatalf("decodeBase64() error = %v", err)
+	}
+	iterations := raw.List[0].Iterations
+
+	if _, err := decryptKey(blob, iterations, "1Password"); err != nil {
+		t.Fatalf("decryptKey() on the intact blob error = %v", err)
+	}
+
+	// flipping the last byte garbles the padding of the final block
+	tampered := append([]byte(nil), blob...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	truncated := blob[:len(blob)-1]
+
+	for name, corrupted := range map[string][]byte{"tampered": tampered, "truncated": truncated} {
+		key, err := decryptKey(corrupted, iterations, "1Password")
+		if err == nil {
+			t.Errorf("decryptKey() on %s blob = %x, want error", name, key)
+		}
+	}
+}
+
+func TestParseRawEncryptionKey_DecryptFails(t *testing.T) {
+	keychain := &AgileKeychain{baseDir: example1Path}
+	raw, err := keychain.readRawEncryptionKeys()
+	if err != nil {
+		t.Fatalf("readRawEncryptionKeys() error = %v", err)
+	}
+	rawKey := raw.List[0]
+
+	blob, err := decodeBase64(rawKey.Data)
+	if err != nil {
+		t.Fatalf("decodeBase64() error = %v", err)
+	}
+	blob[len(blob)-1] ^= 0xff
+	rawKey.Data = base64.StdEncoding.EncodeToString(blob)
+
+	key, err := parseRawEncryptionKey(rawKey, "1Password", ValidationKDFAuto)
+	if err == nil {
+		t.Fatalf("parseRawEncryptionKey() with a corrupted key = %x, want error", key.key)
+	}
+	if !strings.Contains(err.Error(), "Failed to decrypt key") {
+		t.Errorf("parseRawEncryptionKey() error = %v, want a decryption failure", err)
+	}
+	if !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("parseRawEncryptionKey() error = %v, want ErrWrongPassphrase", err)
+	}
+	if key.key != nil {
+		t.Errorf("parseRawEncryptionKey() returned key bytes %x for a key that didn't decrypt", key.key)
+	}
+}
+
+func TestNewAgileKeychain_NoDescriptorLeak(t *testing.T) {
+	countFDs := func() int {
+		fds, err := ioutil.ReadDir("/proc/self/fd")
+		if err != nil {
+			t.Skipf("Can't count open descriptors: %v", err)
+		}
+		return len(fds)
+	}
+
+	for _, opts := range [][]Option{nil, {WithSharedRead(true)}} {
+		before := countFDs()
+		for i := 0; i < 50; i++ {
+			keychain, err := NewAgileKeychain(example1Path, "1Password", opts...)
+			if err != nil {
+				t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+			}
+			if _, err := keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C"); err != nil {
+				t.Fatalf("DecryptItem() error = %v", err)
+			}
+			keychain.Close()
+		}
+
+		// allow for descriptors the runtime opens in the meantime
+		if after := countFDs(); after > before+5 {
+			t.Errorf("%d descriptors open after opening 50 keychains, %d before", after, before)
+		}
+	}
+}
+
+func TestExtractSalt(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantSalt []byte
+		wantBlob []byte
+		wantErr  bool
+	}{
+		{name: "Empty", input: []byte{}, wantErr: true},
+		{name: "5 bytes", input: []byte("Salte"), wantErr: true},
+		{name: "12 bytes", input: []byte("Salted__abcd"), wantErr: true},
+		{name: "No magic", input: []byte("NotSalt_abcdefghblob"), wantErr: true},
+		{name: "Salt only", input: []byte("Salted__abcdefgh"), wantSalt: []byte("abcdefgh"), wantBlob: []byte{}},
+		{name: "Salt and blob", input: []byte("Salted__abcdefghblob"), wantSalt: []byte("abcdefgh"), wantBlob: []byte("blob")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			salt, blob, err := extractSalt(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractSalt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(salt, tt.wantSalt) || !bytes.Equal(blob, tt.wantBlob) {
+				t.Errorf("extractSalt() = %q, %q, want %q, %q", salt, blob, tt.wantSalt, tt.wantBlob)
+			}
+		})
+	}
+}
+
+func TestSameMasterKey(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	sameKeys, err := NewAgileKeychain(copyFixture(t), "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	otherKeys, err := NewAgileKeychain("../testdata/agilekeychain/validationkdf/openssl/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		other *AgileKeychain
+		want  bool
+	}{
+		{name: "Itself", other: keychain, want: true},
+		{name: "Copy", other: sameKeys, want: true},
+		{name: "Different keychain", other: otherKeys, want: false},
+	}
+	for _, tt := range tests {
+		got, err := keychain.SameMasterKey(tt.other)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: SameMasterKey() = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	sameKeys.Close()
+	if _, err := keychain.SameMasterKey(sameKeys); !errors.Is(err, ErrLocked) {
+		t.Errorf("SameMasterKey() with a closed keychain error = %v, want ErrLocked", err)
+	}
+}
+
+func TestDeriveOpensslKey(t *testing.T) {
+	// from openssl enc -aes-128-cbc / -aes-256-cbc -md md5 -pass pass:password
+	// -S 0102030405060708 -P
+	salt := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	tests := []struct {
+		keyLen  int
+		wantKey string
+		wantIV  string
+	}{
+		{keyLen: 16, wantKey: "E7B0971E52CA5CC8D0539FB3412F6316", wantIV: "F7BA2E6EE293D9F3457B99436B51CE02"},
+		{keyLen: 32, wantKey: "E7B0971E52CA5CC8D0539FB3412F6316F7BA2E6EE293D9F3457B99436B51CE02", wantIV: "8D450E2ED75A84A923D4EAC9FE49226B"},
+	}
+	for _, tt := range tests {
+		password := []byte("password")
+		key, iv := deriveOpensslKey(password, salt, tt.keyLen, 16)
+		if got := strings.ToUpper(hex.EncodeToString(key)); got != tt.wantKey {
+			t.Errorf("deriveOpensslKey(%d) key = %s, want %s", tt.keyLen, got, tt.wantKey)
+		}
+		if got := strings.ToUpper(hex.EncodeToString(iv)); got != tt.wantIV {
+			t.Errorf("deriveOpensslKey(%d) iv = %s, want %s", tt.keyLen, got, tt.wantIV)
+		}
+		if string(password) != "password" {
+			t.Errorf("deriveOpensslKey() modified the password: %q", password)
+		}
+	}
+}
+
+func TestNewAgileKeychain_WithBaseDir(t *testing.T) {
+	fixtureDir, err := filepath.Abs("../testdata/agilekeychain/example1")
+	if err != nil {
+		t.Fatalf("Failed to resolve fixture path: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		baseDir string
+		wantErr bool
+	}{
+		{name: "Relative to base", path: "1Password.agilekeychain", baseDir: fixtureDir},
+		{name: "Relative base", path: "1Password.agilekeychain", baseDir: "../testdata/agilekeychain/example1"},
+		{name: "Absolute path ignores base", path: path.Join(fixtureDir, "1Password.agilekeychain"), baseDir: t.TempDir()},
+		{name: "Not relative to cwd", path: example1Path, baseDir: t.TempDir(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keychain, err := NewAgileKeychain(tt.path, "1Password", WithBaseDir(tt.baseDir))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAgileKeychain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && keychain.baseDir != path.Join(fixtureDir, "1Password.agilekeychain") {
+				t.Errorf("NewAgileKeychain() opened %s", keychain.baseDir)
+			}
+		})
+	}
+}
+
+func TestIsAgileKeychain(t *testing.T) {
+	tests := map[string]bool{
+		example1Path: true,
+		"../testdata/agilekeychain/base64wrapped/1Password.agilekeychain": true,
+		"../testdata/opvault/example1/passync.opvault":                    false,
+		"/nonexist4329489erjgar":                                          false,
+	}
+	for keychainPath, want := range tests {
+		if got := IsAgileKeychain(keychainPath); got != want {
+			t.Errorf("IsAgileKeychain(%s) = %v, want %v", keychainPath, got, want)
+		}
+	}
+}
+
+func TestNewAgileKeychain_ExtraContentsElements(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/extraelements/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	items := keychain.List()
+	if len(items) != 2 || items[0].Title != "Hulu" || items[1].Title != "Tumblr" {
+		t.Fatalf("List() = %+v", items)
+	}
+	if _, err := keychain.DecryptItem(items[1].ID); err != nil {
+		t.Errorf("DecryptItem() error = %v", err)
+	}
+
+	want := map[string][]interface{}{
+		"13C8E12AC8E54B1F873BAB0824E521BC": {map[string]interface{}{"favorite": true}},
+		"5ADFF73C09004C448D45565BC4750DE2": {"extra", json.Number("42")},
+	}
+	if got := keychain.DebugContents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DebugContents() = %#v, want %#v", got, want)
+	}
+
+	plain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	if got := plain.DebugContents(); len(got) != 0 {
+		t.Errorf("DebugContents() = %#v for 8-element entries, want empty", got)
+	}
+}
+
+func TestWithSkipBadEntries(t *testing.T) {
+	keychainPath := copyFixture(t)
+	appendContentsEntries(t, keychainPath,
+		`["0123456789ABCDEF0123456789ABCDE1","webforms.WebForm"]`,
+		`[null,"webforms.WebForm","No id","",0,"",0,"N"]`,
+	)
+
+	if _, err := NewAgileKeychain(keychainPath, "1Password"); err == nil {
+		t.Error("NewAgileKeychain() with bad entries error = nil, want error")
+	}
+	if _, err := NewAgileKeychain(keychainPath, "1Password", WithSkipBadEntries(false)); err == nil {
+		t.Error("NewAgileKeychain(WithSkipBadEntries(false)) with bad entries error = nil, want error")
+	}
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password", WithSkipBadEntries(true))
+	if err != nil {
+		t.Fatalf("NewAgileKeychain(WithSkipBadEntries(true)) error = %v", err)
+	}
+	if len(keychain.List()) != 19 {
+		t.Errorf("List() has %d items, want the fixture's 19", len(keychain.List()))
+	}
+	skipped := keychain.SkippedEntries()
+	if len(skipped) != 2 || !strings.Contains(skipped[0].Error(), "entry 19") || !strings.Contains(skipped[1].Error(), "entry 20") {
+		t.Errorf("SkippedEntries() = %v, want entries 19 and 20", skipped)
+	}
+
+	data, err := ioutil.ReadFile(path.Join(keychainPath, "data", "default", "contents.js"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	items, err := ParseContents(data, WithSkipBadEntries(true))
+	if len(items) != 19 || err == nil || !strings.Contains(err.Error(), "entry 20") {
+		t.Errorf("ParseContents(WithSkipBadEntries) = %d items, %v", len(items), err)
+	}
+}
+
+func TestNewAgileKeychain_TypedErrors(t *testing.T) {
+	notDir := path.Join(t.TempDir(), "file")
+	if err := ioutil.WriteFile(notDir, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	malformed := copyFixture(t)
+	appendContentsEntries(t, malformed, `["0123456789ABCDEF0123456789ABCDE1"]`)
+
+	tests := []struct {
+		name       string
+		path       string
+		passphrase string
+		want       error
+	}{
+		{"Missing", "/nonexist4329489erjgar", "1Password", os.ErrNotExist},
+		{"Not a directory", notDir, "1Password", ErrNotADirectory},
+		{"Malformed contents", malformed, "1Password", ErrMalformedContents},
+		{"Wrong passphrase", example1Path, "wrong", ErrWrongPassphrase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAgileKeychain(tt.path, tt.passphrase)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("NewAgileKeychain() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateKey_TypedError(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	validation, err := keychain.ValidationBlob("SL5")
+	if err != nil {
+		t.Fatalf("ValidationBlob() error = %v", err)
+	}
+
+	wrongKey := append([]byte{}, keychain.encKeys.sl5.key...)
+	wrongKey[0] ^= 1
+	for _, kdf := range []ValidationKDF{ValidationKDFAuto, ValidationKDFOpenSSL, ValidationKDFPBKDF2} {
+		if err := validateKey(wrongKey, validation, keychain.encKeys.sl5.iterations, kdf); !errors.Is(err, ErrKeyValidationFailed) {
+			t.Errorf("validateKey(wrong key, %v) error = %v, want ErrKeyValidationFailed", kdf, err)
+		}
+	}
+	if err := validateKey(keychain.encKeys.sl5.key, validation[:4], 1000, ValidationKDFOpenSSL); !errors.Is(err, ErrKeyValidationFailed) {
+		t.Errorf("validateKey(short blob) error = %v, want ErrKeyValidationFailed", err)
+	}
+}
+
+func TestParseContents_LargeTimestamp(t *testing.T) {
+	// 2^53 + 1 milliseconds, which float64 would round to 2^53
+	data := []byte(`[["A","webforms.WebForm","One","",9007199254740993,"",0,"N"]]`)
+	want := time.Unix(9007199254740, 993*int64(time.Millisecond))
+
+	items, err := ParseContents(data, WithDateUnit(DateUnitMilliseconds))
+	if err != nil {
+		t.Fatalf("ParseContents() error = %v", err)
+	}
+	if !items[0].Date.Equal(want) {
+		t.Errorf("ParseContents() date = %v, want %v", items[0].Date, want)
+	}
+
+	// json.Number entries passed to ParseContentsEntry are exact too
+	item, err := ParseContentsEntry([]interface{}{"A", "webforms.WebForm", "One", "", json.Number("1362350139001"), "", json.Number("42"), "N"})
+	if err != nil {
+		t.Fatalf("ParseContentsEntry() error = %v", err)
+	}
+	if want := time.Unix(1362350139, int64(time.Millisecond)); !item.Date.Equal(want) || item.PasswordStrength != 42 {
+		t.Errorf("ParseContentsEntry() = %+v, want date %v and strength 42", item, want)
+	}
+
+	if _, err := ParseContents([]byte(`[] []`)); err == nil {
+		t.Error("ParseContents() with trailing data error = nil, want error")
+	}
+
+	// numbers past the range of an int64 fail the entry rather than wrap
+	for _, date := range []string{"1e300", "-1e300", "9223372036854775808"} {
+		data := []byte(`[["A","webforms.WebForm","One","",` + date + `,"",0,"N"]]`)
+		if items, err := ParseContents(data); err == nil {
+			t.Errorf("ParseContents() with date %s = %+v, want error", date, items)
+		}
+	}
+	if _, ok := optionalNumber(float64(1 << 63)); ok {
+		t.Error("optionalNumber(2^63) ok = true, want false")
+	}
+	if n, ok := optionalNumber(float64(-1 << 63)); !ok || n != math.MinInt64 {
+		t.Errorf("optionalNumber(-2^63) = %d, %v, want %d", n, ok, int64(math.MinInt64))
+	}
+}
+
+func TestDecodeBase64(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Plain", input: "aGVsbG8=", want: "hello"},
+		{name: "One NUL", input: "aGVsbG8=\u0000", want: "hello"},
+		{name: "Several NULs", input: "aGVsbG8=\u0000\u0000\u0000", want: "hello"},
+		{name: "Missing padding", input: "aGVsbG8", want: "hello"},
+		{name: "Missing padding and NULs", input: "aGVsbA\u0000\u0000", want: "hell"},
+		{name: "Empty", input: "\u0000", want: ""},
+		{name: "NUL in the middle", input: "aGVs\u0000bG8=", wantErr: true},
+		{name: "Not base64", input: "!!!!", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := decodeBase64(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: decodeBase64() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && string(got) != tt.want {
+			t.Errorf("%s: decodeBase64() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
 }
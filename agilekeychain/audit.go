@@ -0,0 +1,307 @@
+package agilekeychain
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Severity ranks how serious an audit finding is
+type Severity int
+
+const (
+	// SeverityLow findings are worth knowing about
+	SeverityLow Severity = iota
+	// SeverityMedium findings weaken the protection of some items
+	SeverityMedium
+	// SeverityHigh findings put items at immediate risk
+	SeverityHigh
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// FindingKind identifies the check that produced an audit finding
+type FindingKind string
+
+// The checks run by SecurityAudit
+const (
+	FindingReusedPassword FindingKind = "reused-password"
+	FindingWeakIterations FindingKind = "weak-iterations"
+	FindingInsecureURL    FindingKind = "insecure-url"
+	FindingExpired        FindingKind = "expired"
+)
+
+// Finding is a single issue found by SecurityAudit
+type Finding struct {
+	Kind     FindingKind
+	Severity Severity
+	ItemIDs  []string
+	Detail   string
+}
+
+// AuditReport is the result of SecurityAudit, with the most severe findings
+// first
+type AuditReport struct {
+	Findings []Finding
+}
+
+// PBKDF2 iteration counts below this are flagged by SecurityAudit
+const minRecommendedIterations = 100000
+
+// find an item's password: logins keep it in a designated form field, most
+// other item types in a top-level "password" key
+func itemPassword(data map[string]interface{}) string {
+	_, password := loginCredentials(data)
+	if password != "" {
+		return password
+	}
+
+	password, _ = data["password"].(string)
+	return password
+}
+
+// CheckPwned reports whether the password of the item with the given id
+// appears in a breach corpus, using a k-anonymity range lookup in the style of
+// the HaveIBeenPwned API.  The password is SHA-1 hashed, and only the first
+// five hex digits of the hash are passed to hashPrefixLookup, which should
+// return the hash suffixes (optionally followed by ":count") found for that
+// prefix.  This package makes no network calls itself.
+func (k *AgileKeychain) CheckPwned(id string, hashPrefixLookup func(prefix string) ([]string, error)) (bool, error) {
+	data, err := k.DecryptItem(id)
+	if err != nil {
+		return false, err
+	}
+
+	password := itemPassword(data)
+	if password == "" {
+		return false, fmt.Errorf("Item %s has no password", id)
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	candidates, err := hashPrefixLookup(prefix)
+	if err != nil {
+		return false, err
+	}
+
+	for _, candidate := range candidates {
+		candidate = strings.SplitN(strings.TrimSpace(candidate), ":", 2)[0]
+		if strings.EqualFold(candidate, suffix) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SecurityAudit decrypts every item and reports, in one place:
+//
+//   - passwords shared by more than one item
+//   - encryption keys derived with too few PBKDF2 iterations
+//   - items whose URL is plain http
+//   - items (such as credit cards) whose expiry date has passed
+//
+// Items that fail to decrypt are reported in an ItemErrors alongside the
+// findings for the rest of the keychain.
+func (k *AgileKeychain) SecurityAudit(passphrase string) (AuditReport, error) {
+	var report AuditReport
+
+	err := k.loadEncryptionKeys(passphrase)
+	if err != nil {
+		return report, err
+	}
+
+	now := time.Now()
+	passwords := make(map[string]string)
+	itemsByKey := make(map[string][]string)
+	failures := ItemErrors{}
+
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		item, err := k.loadItemFile(entry.id)
+		if err != nil {
+			failures[entry.id] = err
+			continue
+		}
+
+		if key, err := k.keyForItem(item); err == nil {
+			itemsByKey[key.id] = append(itemsByKey[key.id], entry.id)
+		}
+
+		if isInsecureURL(item.Location) {
+			report.Findings = append(report.Findings, insecureURLFinding(entry, item.Location))
+		}
+
+		data, err := k.DecryptItem(entry.id)
+		if err != nil {
+			failures[entry.id] = err
+			continue
+		}
+
+		if password := itemPassword(data); password != "" {
+			passwords[entry.id] = password
+		}
+
+		if expiry, ok := itemExpiry(data); ok && expiry.Before(now) {
+			report.Findings = append(report.Findings, Finding{
+				Kind:     FindingExpired,
+				Severity: SeverityLow,
+				ItemIDs:  []string{entry.id},
+				Detail:   fmt.Sprintf("%s expired %s", entry.title, expiry.Format("2006-01")),
+			})
+		}
+	}
+
+	for _, ids := range reusedPasswords(passwords) {
+		report.Findings = append(report.Findings, Finding{
+			Kind:     FindingReusedPassword,
+			Severity: SeverityHigh,
+			ItemIDs:  ids,
+			Detail:   fmt.Sprintf("%d items share a password", len(ids)),
+		})
+	}
+
+	encKeys, _ := k.keys()
+	for _, key := range encKeys.keys {
+		if key.iterations < minRecommendedIterations {
+			report.Findings = append(report.Findings, weakIterationsFinding(key.id, key.iterations, itemsByKey[key.id]))
+		}
+	}
+
+	sortFindings(report.Findings)
+
+	if len(failures) > 0 {
+		return report, failures
+	}
+	return report, nil
+}
+
+func insecureURLFinding(entry keychainContentsEntry, location string) Finding {
+	return Finding{
+		Kind:     FindingInsecureURL,
+		Severity: SeverityMedium,
+		ItemIDs:  []string{entry.id},
+		Detail:   fmt.Sprintf("%s uses an unencrypted URL: %s", entry.title, location),
+	}
+}
+
+func weakIterationsFinding(keyID string, iterations int, itemIDs []string) Finding {
+	return Finding{
+		Kind:     FindingWeakIterations,
+		Severity: SeverityMedium,
+		ItemIDs:  itemIDs,
+		Detail:   fmt.Sprintf("Key %s uses %d PBKDF2 iterations, fewer than the recommended %d", keyID, iterations, minRecommendedIterations),
+	}
+}
+
+// put the most severe findings first, in a stable order
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return strings.Join(a.ItemIDs, ",") < strings.Join(b.ItemIDs, ",")
+	})
+}
+
+// group the ids of items that share a password, given a map of id to
+// password.  Only groups with more than one member are returned.
+func reusedPasswords(passwords map[string]string) [][]string {
+	byPassword := make(map[string][]string)
+	for id, password := range passwords {
+		byPassword[password] = append(byPassword[password], id)
+	}
+
+	var ret [][]string
+	for _, ids := range byPassword {
+		if len(ids) > 1 {
+			sort.Strings(ids)
+			ret = append(ret, ids)
+		}
+	}
+	return ret
+}
+
+func isInsecureURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && strings.EqualFold(u.Scheme, "http")
+}
+
+// items with an expiry date keep it as separate month and year fields; the
+// expiry is the end of that month
+func itemExpiry(data map[string]interface{}) (time.Time, bool) {
+	month, err := strconv.Atoi(fmt.Sprint(data["expiry_mm"]))
+	if err != nil || month < 1 || month > 12 {
+		return time.Time{}, false
+	}
+
+	year, err := strconv.Atoi(fmt.Sprint(data["expiry_yy"]))
+	if err != nil {
+		return time.Time{}, false
+	}
+	if year < 100 {
+		year += 2000
+	}
+
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// ReusedKeySalt reports whether any of the keychain's encryption keys were
+// derived from the passphrase with the same PBKDF2 salt, which would make
+// them weaker than intended, and if so the identifiers of the keys involved.
+// Only the unencrypted salts are read.
+func (k *AgileKeychain) ReusedKeySalt() (bool, []string, error) {
+	raw, err := k.readRawEncryptionKeys()
+	if err != nil {
+		return false, nil, err
+	}
+
+	bySalt := make(map[string][]string)
+	for _, rawKey := range raw.List {
+		blob, err := decodeBase64(rawKey.Data)
+		if err != nil {
+			return false, nil, fmt.Errorf("Failed to decode key %s: %v", rawKey.Identifier, err)
+		}
+
+		salt, _, err := extractSalt(blob)
+		if err != nil {
+			return false, nil, fmt.Errorf("Failed to read salt of key %s: %v", rawKey.Identifier, err)
+		}
+
+		bySalt[string(salt)] = append(bySalt[string(salt)], rawKey.Identifier)
+	}
+
+	var offending []string
+	for _, ids := range bySalt {
+		if len(ids) > 1 {
+			offending = append(offending, ids...)
+		}
+	}
+	sort.Strings(offending)
+
+	return len(offending) > 0, offending, nil
+}
@@ -0,0 +1,157 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestCheckPwned(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/example1/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	// SHA-1 of the Hulu password "frirp7i1ob7wig4d", split after five digits
+	huluPrefix := "2DFF5"
+	huluSuffix := "C8C190824165E2075E6F796A6D049F4FDC7"
+
+	var gotPrefix string
+	pwned := func(prefix string) ([]string, error) {
+		gotPrefix = prefix
+		return []string{"0000000000000000000000000000000000A:3", huluSuffix + ":12"}, nil
+	}
+	clean := func(prefix string) ([]string, error) {
+		return []string{"0000000000000000000000000000000000A:3"}, nil
+	}
+	broken := func(prefix string) ([]string, error) {
+		return nil, errors.New("lookup failed")
+	}
+
+	found, err := keychain.CheckPwned("13C8E12AC8E54B1F873BAB0824E521BC", pwned)
+	if err != nil || !found {
+		t.Errorf("CheckPwned() = %v, %v, want true", found, err)
+	}
+	if gotPrefix != huluPrefix {
+		t.Errorf("Lookup got prefix %s, want %s", gotPrefix, huluPrefix)
+	}
+
+	found, err = keychain.CheckPwned("13C8E12AC8E54B1F873BAB0824E521BC", clean)
+	if err != nil || found {
+		t.Errorf("CheckPwned() = %v, %v, want false", found, err)
+	}
+
+	_, err = keychain.CheckPwned("13C8E12AC8E54B1F873BAB0824E521BC", broken)
+	if err == nil {
+		t.Errorf("CheckPwned() with failing lookup did not fail")
+	}
+
+	// the secure note has no password to check
+	_, err = keychain.CheckPwned("D1820AA8CB534AC6A4B5A2C0263FD3B2", pwned)
+	if err == nil {
+		t.Errorf("CheckPwned() of item without password did not fail")
+	}
+}
+
+func TestSecurityAudit(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/example1/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	report, err := keychain.SecurityAudit("1Password")
+	if err != nil {
+		t.Fatalf("SecurityAudit() error = %v", err)
+	}
+
+	counts := make(map[FindingKind]int)
+	for ix, finding := range report.Findings {
+		counts[finding.Kind]++
+		if len(finding.ItemIDs) == 0 {
+			t.Errorf("Finding %+v has no items", finding)
+		}
+		if ix > 0 && finding.Severity > report.Findings[ix-1].Severity {
+			t.Errorf("Findings not sorted by severity: %+v after %+v", finding, report.Findings[ix-1])
+		}
+	}
+
+	want := map[FindingKind]int{
+		// both fixture keys use 10000 iterations
+		FindingWeakIterations: 2,
+		// Hulu, YouTube, Tumblr and TUAW
+		FindingInsecureURL: 4,
+		// both credit cards
+		FindingExpired: 2,
+	}
+	for kind, n := range want {
+		if counts[kind] != n {
+			t.Errorf("Got %d %s findings, want %d", counts[kind], kind, n)
+		}
+	}
+	if counts[FindingReusedPassword] != 0 {
+		t.Errorf("Got reused password findings in fixture with unique passwords")
+	}
+
+	_, err = keychain.SecurityAudit("wrong passphrase")
+	if err == nil {
+		t.Errorf("SecurityAudit() with wrong passphrase did not fail")
+	}
+}
+
+func TestReusedPasswords(t *testing.T) {
+	got := reusedPasswords(map[string]string{
+		"A": "hunter2",
+		"B": "correct horse",
+		"C": "hunter2",
+		"D": "unique",
+	})
+
+	if len(got) != 1 || len(got[0]) != 2 || got[0][0] != "A" || got[0][1] != "C" {
+		t.Errorf("reusedPasswords() = %v, want [[A C]]", got)
+	}
+}
+
+func TestReusedKeySalt(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	reused, ids, err := keychain.ReusedKeySalt()
+	if err != nil || reused || len(ids) != 0 {
+		t.Errorf("ReusedKeySalt() = %v, %v, %v, want no reuse", reused, ids, err)
+	}
+
+	// give the SL3 key the SL5 key's data, and so its salt
+	keysPath := path.Join(keychainPath, "data", "default", "encryptionKeys.js")
+	var raw map[string]interface{}
+	data, err := ioutil.ReadFile(keysPath)
+	if err != nil {
+		t.Fatalf("Failed to read keys: %v", err)
+	}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		t.Fatalf("Failed to parse keys: %v", err)
+	}
+	list := raw["list"].([]interface{})
+	list[0].(map[string]interface{})["data"] = list[1].(map[string]interface{})["data"]
+	data, err = json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("Failed to marshal keys: %v", err)
+	}
+	err = ioutil.WriteFile(keysPath, data, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write keys: %v", err)
+	}
+
+	reused, ids, err = keychain.ReusedKeySalt()
+	want := []string{"91F7E2D5E3E54447819ABDD84CFB27A2", "C6EA4955FD224185BD2A4579C89CA9D3"}
+	if err != nil || !reused || !reflect.DeepEqual(ids, want) {
+		t.Errorf("ReusedKeySalt() = %v, %v, %v, want true, %v", reused, ids, err, want)
+	}
+}
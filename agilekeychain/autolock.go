@@ -0,0 +1,76 @@
+package agilekeychain
+
+import (
+	"time"
+)
+
+// WithAutoLock makes the keychain lock itself, as Close does, once timeout
+// has passed without an item being decrypted.  Each decryption restarts the
+// timer, and keys reloaded by a method that takes a passphrase are locked
+// again in the same way.  A zero timeout, the default, never locks.  The
+// AgileKeychain has no auto-lock setting of its own to read, so the timeout
+// is the caller's to choose.
+func WithAutoLock(timeout time.Duration) Option {
+	return func(k *AgileKeychain) {
+		k.autoLockTimeout = timeout
+	}
+}
+
+// start the auto-lock timer, if there is a timeout, replacing any timer
+// already running.  Called whenever keys are loaded, so that keys reloaded
+// by a method taking a passphrase after the keychain locked are locked again
+// in turn.
+func (k *AgileKeychain) startAutoLock() {
+	k.keyMu.Lock()
+	defer k.keyMu.Unlock()
+	if k.autoLockTimer != nil {
+		k.autoLockTimer.Stop()
+		k.autoLockTimer = nil
+	}
+	if k.autoLockTimeout <= 0 {
+		return
+	}
+	k.autoLockTimer = time.AfterFunc(k.autoLockTimeout, func() { k.Close() })
+}
+
+// Close locks the keychain: the decrypted encryption keys are zeroed and
+// dropped, and from then on decrypting an item fails with ErrLocked.
+// Metadata from contents.js remains available.  Decryptions already under
+// way are allowed to finish first.
+func (k *AgileKeychain) Close() error {
+	k.keyUse.Lock()
+	defer k.keyUse.Unlock()
+	k.keyMu.Lock()
+	defer k.keyMu.Unlock()
+
+	if k.autoLockTimer != nil {
+		k.autoLockTimer.Stop()
+		k.autoLockTimer = nil
+	}
+
+	zeroKeys(k.encKeys)
+	k.encKeys = encryptionKeys{}
+	return nil
+}
+
+// overwrite the decrypted key bytes in keys with zeroes
+func zeroKeys(keys encryptionKeys) {
+	for _, key := range keys.keys {
+		clear(key.key)
+	}
+}
+
+// the loaded encryption keys, or ErrLocked if there are none.  Restarts the
+// auto-lock timer.
+func (k *AgileKeychain) keys() (encryptionKeys, error) {
+	k.keyMu.Lock()
+	defer k.keyMu.Unlock()
+
+	if k.encKeys.keys == nil {
+		return k.encKeys, ErrLocked
+	}
+	if k.autoLockTimer != nil {
+		k.autoLockTimer.Reset(k.autoLockTimeout)
+	}
+	return k.encKeys, nil
+}
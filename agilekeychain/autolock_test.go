@@ -0,0 +1,96 @@
+package agilekeychain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClose(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	key := keychain.encKeys.sl5.key
+	if err := keychain.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	for _, b := range key {
+		if b != 0 {
+			t.Fatalf("Key not zeroed by Close: %x", key)
+		}
+	}
+	if _, err := keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C"); !errors.Is(err, ErrLocked) {
+		t.Errorf("DecryptItem() after Close error = %v, want ErrLocked", err)
+	}
+	if levels := keychain.SecurityLevels(); len(levels) != 0 {
+		t.Errorf("SecurityLevels() after Close = %v, want none", levels)
+	}
+	if _, err := keychain.GetItem("4E36C011EE8348B1B24418218B04018C"); err != nil {
+		t.Errorf("GetItem() after Close error = %v", err)
+	}
+}
+
+func TestLoadEncryptionKeys_ZeroesReplacedKeys(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	old := keychain.encKeys.sl5.key
+	if err := keychain.loadEncryptionKeys("1Password"); err != nil {
+		t.Fatalf("loadEncryptionKeys() error = %v", err)
+	}
+
+	for _, b := range old {
+		if b != 0 {
+			t.Fatalf("Replaced key not zeroed: %x", old)
+		}
+	}
+	if _, err := keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C"); err != nil {
+		t.Errorf("DecryptItem() with reloaded keys error = %v", err)
+	}
+}
+
+func TestWithAutoLock(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password", WithAutoLock(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	if _, err := keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C"); err != nil {
+		t.Fatalf("DecryptItem() before the timeout error = %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if _, err := keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C"); !errors.Is(err, ErrLocked) {
+		t.Errorf("DecryptItem() after the timeout error = %v, want ErrLocked", err)
+	}
+
+	// a method taking the passphrase reloads the keys, and the timer with
+	// them
+	if _, _, err := keychain.DecryptStats("1Password"); err != nil {
+		t.Fatalf("DecryptStats() after the timeout error = %v", err)
+	}
+	keychain.keyMu.Lock()
+	restarted := keychain.autoLockTimer != nil
+	keychain.keyMu.Unlock()
+	if !restarted {
+		t.Fatalf("Auto-lock timer not restarted when keys were reloaded")
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if levels := keychain.SecurityLevels(); len(levels) != 0 {
+		t.Errorf("SecurityLevels() after the reloaded keys timed out = %v, want none", levels)
+	}
+
+	keychain, err = NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	if keychain.autoLockTimer != nil {
+		t.Errorf("Auto-lock timer started without WithAutoLock")
+	}
+}
@@ -0,0 +1,54 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// WithChangelog makes the keychain append a record to w of each change it
+// makes to the keychain on disk, for an audit trail of what was modified.
+// Each record is a line of JSON with the time of the change in UTC, the
+// operation, the id of the item changed and, for some operations, a detail
+// such as "SL3 to SL5" for RelevelItem.  Records never hold decrypted data.
+// The keychain serializes its own writes to w; a w shared with other
+// keychains must do its own locking.
+func WithChangelog(w io.Writer) Option {
+	return func(k *AgileKeychain) {
+		k.changelog = w
+	}
+}
+
+type changelogRecord struct {
+	Time   time.Time `json:"time"`
+	Op     string    `json:"op"`
+	ID     string    `json:"id"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// record a change in the changelog, if there is one.  The change has already
+// been made, so a failure is reported as such.
+func (k *AgileKeychain) logChange(op string, id string, detail string) error {
+	if k.changelog == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(changelogRecord{
+		Time:   time.Now().UTC(),
+		Op:     op,
+		ID:     id,
+		Detail: detail,
+	})
+	if err != nil {
+		return err
+	}
+
+	k.changelogMu.Lock()
+	defer k.changelogMu.Unlock()
+	_, err = k.changelog.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("Item %s changed but the changelog couldn't be written: %v", id, err)
+	}
+	return nil
+}
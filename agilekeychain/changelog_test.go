@@ -0,0 +1,63 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithChangelog(t *testing.T) {
+	var changelog bytes.Buffer
+	keychain, err := NewAgileKeychain(copyFixture(t), "1Password", WithChangelog(&changelog))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	// Tumblr starts out at SL5
+	id := "5ADFF73C09004C448D45565BC4750DE2"
+	before := time.Now().Add(-time.Second)
+	if err := keychain.RelevelItem(id, "SL3"); err != nil {
+		t.Fatalf("RelevelItem() error = %v", err)
+	}
+	if err := keychain.RelevelItem(id, "SL5"); err != nil {
+		t.Fatalf("RelevelItem() error = %v", err)
+	}
+	if _, err := keychain.DecryptItem(id); err != nil {
+		t.Fatalf("DecryptItem() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(changelog.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("changelog = %q, want 2 records", changelog.String())
+	}
+	for ix, wantDetail := range []string{"SL5 to SL3", "SL3 to SL5"} {
+		var record changelogRecord
+		if err := json.Unmarshal([]byte(lines[ix]), &record); err != nil {
+			t.Fatalf("changelog record %q: %v", lines[ix], err)
+		}
+		if record.Op != "relevel" || record.ID != id || record.Detail != wantDetail || record.Time.Before(before) {
+			t.Errorf("changelog record %d = %+v", ix, record)
+		}
+	}
+	if strings.Contains(changelog.String(), "vow6wem2wo") {
+		t.Error("changelog contains a decrypted secret")
+	}
+}
+
+func TestWithChangelog_WriteFails(t *testing.T) {
+	keychain, err := NewAgileKeychain(copyFixture(t), "1Password", WithChangelog(failingWriter{}))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	err = keychain.RelevelItem("5ADFF73C09004C448D45565BC4750DE2", "SL3")
+	if err == nil {
+		t.Fatal("RelevelItem() with a failing changelog error = nil, want error")
+	}
+	// the change itself was still made
+	if level, err := keychain.ItemSecurityLevel("5ADFF73C09004C448D45565BC4750DE2"); err != nil || level != "SL3" {
+		t.Errorf("ItemSecurityLevel() = %q, %v, want SL3", level, err)
+	}
+}
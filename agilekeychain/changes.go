@@ -0,0 +1,90 @@
+package agilekeychain
+
+import "sort"
+
+// ItemChanges lists the ids of items that changed between two snapshots of a
+// keychain.  Each list is sorted.
+type ItemChanges struct {
+	Added    []string
+	Removed  []string
+	Modified []string
+}
+
+// Len is the total number of changed items
+func (c ItemChanges) Len() int {
+	return len(c.Added) + len(c.Removed) + len(c.Modified)
+}
+
+// ChangeTracker reports which items in a keychain changed across calls to
+// Reload.  Items are compared by the contentsHash in their item files, so
+// nothing is decrypted, but every item file is read on each Reload.
+type ChangeTracker struct {
+	keychain *AgileKeychain
+	hashes   map[string]string
+}
+
+// NewChangeTracker snapshots the current state of keychain, which later
+// calls to Reload are compared against
+func NewChangeTracker(keychain *AgileKeychain) (*ChangeTracker, error) {
+	hashes, err := keychain.contentsHashes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangeTracker{keychain: keychain, hashes: hashes}, nil
+}
+
+// Reload reloads the keychain and returns the items that changed since the
+// tracker was created or last reloaded.  On error the previous snapshot is
+// kept, so the changes are reported by the next successful Reload.
+func (t *ChangeTracker) Reload() (ItemChanges, error) {
+	var changes ItemChanges
+
+	err := t.keychain.Reload()
+	if err != nil {
+		return changes, err
+	}
+
+	hashes, err := t.keychain.contentsHashes()
+	if err != nil {
+		return changes, err
+	}
+
+	for id, hash := range hashes {
+		oldHash, ok := t.hashes[id]
+		if !ok {
+			changes.Added = append(changes.Added, id)
+		} else if oldHash != hash {
+			changes.Modified = append(changes.Modified, id)
+		}
+	}
+
+	for id := range t.hashes {
+		if _, ok := hashes[id]; !ok {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Modified)
+
+	t.hashes = hashes
+	return changes, nil
+}
+
+// map each item id to the contentsHash from its item file
+func (k *AgileKeychain) contentsHashes() (map[string]string, error) {
+	hashes := make(map[string]string, len(k.contents))
+
+	for _, entry := range k.contents {
+		item, err := k.loadItemFile(entry.id)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes[entry.id] = item.OpenContents.ContentsHash
+	}
+
+	return hashes, nil
+}
@@ -0,0 +1,81 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestChangeTracker(t *testing.T) {
+	keychainPath := copyFixture(t)
+	dataDir := path.Join(keychainPath, "data", "default")
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tracker, err := NewChangeTracker(keychain)
+	if err != nil {
+		t.Fatalf("NewChangeTracker() error = %v", err)
+	}
+
+	changes, err := tracker.Reload()
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if changes.Len() != 0 {
+		t.Errorf("Reload() of unchanged keychain = %+v, want no changes", changes)
+	}
+
+	// modify an item
+	modifiedPath := path.Join(dataDir, "13C8E12AC8E54B1F873BAB0824E521BC.1password")
+	data, err := ioutil.ReadFile(modifiedPath)
+	if err != nil {
+		t.Fatalf("Failed to read item: %v", err)
+	}
+	data = bytes.Replace(data, []byte(`"contentsHash":"af8ce513"`), []byte(`"contentsHash":"00000000"`), 1)
+	err = ioutil.WriteFile(modifiedPath, data, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write item: %v", err)
+	}
+
+	// add an item, reusing the modified item's file
+	err = ioutil.WriteFile(path.Join(dataDir, "0123456789ABCDEF0123456789ABCDEF.1password"), data, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write item: %v", err)
+	}
+	appendContentsEntries(t, keychainPath,
+		`["0123456789ABCDEF0123456789ABCDEF","webforms.WebForm","New","example.com",1362350139,"",0,"N"]`)
+
+	changes, err = tracker.Reload()
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	want := ItemChanges{
+		Added:    []string{"0123456789ABCDEF0123456789ABCDEF"},
+		Modified: []string{"13C8E12AC8E54B1F873BAB0824E521BC"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Reload() = %+v, want %+v", changes, want)
+	}
+	if keychain.Length() != 20 {
+		t.Errorf("Length() after Reload() = %d, want 20", keychain.Length())
+	}
+
+	// remove everything
+	err = ioutil.WriteFile(path.Join(dataDir, "contents.js"), []byte("[]"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write contents: %v", err)
+	}
+
+	changes, err = tracker.Reload()
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if len(changes.Removed) != 20 || len(changes.Added) != 0 || len(changes.Modified) != 0 {
+		t.Errorf("Reload() after emptying contents = %+v, want 20 removed", changes)
+	}
+}
@@ -0,0 +1,22 @@
+package agilekeychain
+
+import (
+	"crypto/aes"
+)
+
+// itemCipher decrypts an item payload, given the master key it was encrypted
+// under and the salt and blob that follow the payload's "Salted__" magic
+type itemCipher func(key []byte, salt []byte, blob []byte) ([]byte, error)
+
+// the ciphers an item file's "cipher" field can name.  AgileKeychain itself
+// only ever uses AES-128-CBC and leaves the field out; any other cipher is
+// rejected unless a Decrypter handles it.
+var itemCiphers = map[string]itemCipher{
+	"":            decryptCBCPayload,
+	"aes-128-cbc": decryptCBCPayload,
+}
+
+func decryptCBCPayload(key []byte, salt []byte, blob []byte) ([]byte, error) {
+	itemKey, iv := deriveOpensslKey(key, salt, 16, aes.BlockSize)
+	return cbcDecrypt(blob, itemKey, iv)
+}
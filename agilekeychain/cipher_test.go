@@ -0,0 +1,62 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+func TestDecryptItem_Cipher(t *testing.T) {
+	keychainPath := copyFixture(t)
+	id := "6C6C6C6C6C6C6C6C6C6C6C6C6C6C6C01"
+	itemPath := path.Join(keychainPath, "data", "default", id+".1password")
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	key := keychain.encKeys.sl5
+
+	encrypted, err := encryptItemPayload(key.key, []byte(`{"notesPlain":"sealed"}`))
+	if err != nil {
+		t.Fatalf("encryptItemPayload() error = %v", err)
+	}
+
+	writeItem := func(cipherName string) {
+		item := map[string]interface{}{
+			"uuid":      id,
+			"typeName":  "securenotes.SecureNote",
+			"keyID":     key.id,
+			"cipher":    cipherName,
+			"encrypted": encrypted,
+		}
+		data, err := json.Marshal(item)
+		if err != nil {
+			t.Fatalf("Failed to marshal item: %v", err)
+		}
+		err = ioutil.WriteFile(itemPath, data, 0644)
+		if err != nil {
+			t.Fatalf("Failed to write item: %v", err)
+		}
+	}
+
+	for _, cipherName := range []string{"", "aes-128-cbc"} {
+		writeItem(cipherName)
+		notes, err := keychain.GetItemNotes(id)
+		if err != nil {
+			t.Fatalf("GetItemNotes() with cipher %q error = %v", cipherName, err)
+		}
+		if notes != "sealed" {
+			t.Errorf("GetItemNotes() with cipher %q = %q, want %q", cipherName, notes, "sealed")
+		}
+	}
+
+	for _, cipherName := range []string{"aes-256-gcm", "rot13"} {
+		writeItem(cipherName)
+		_, err = keychain.DecryptItem(id)
+		if err == nil {
+			t.Errorf("DecryptItem() with unknown cipher %q succeeded", cipherName)
+		}
+	}
+}
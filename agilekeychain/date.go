@@ -0,0 +1,42 @@
+package agilekeychain
+
+import "time"
+
+// DateUnit is the unit used for timestamps in contents.js
+type DateUnit int
+
+const (
+	// DateUnitAuto guesses the unit from the magnitude of each timestamp
+	DateUnitAuto DateUnit = iota
+	// DateUnitSeconds treats timestamps as unix seconds
+	DateUnitSeconds
+	// DateUnitMilliseconds treats timestamps as unix milliseconds
+	DateUnitMilliseconds
+)
+
+// timestamps above this are assumed to be in milliseconds when using
+// DateUnitAuto; in seconds it is well over 30,000 years from now
+const autoMillisecondsThreshold = 1000000000000
+
+// WithDateUnit sets the unit used to interpret contents.js timestamps.  The
+// default is DateUnitAuto.
+func WithDateUnit(unit DateUnit) Option {
+	return func(k *AgileKeychain) {
+		k.dateUnit = unit
+	}
+}
+
+// convert a raw contents.js timestamp into a time.Time
+func parseDate(raw int64, unit DateUnit) time.Time {
+	if unit == DateUnitAuto {
+		unit = DateUnitSeconds
+		if raw > autoMillisecondsThreshold || raw < -autoMillisecondsThreshold {
+			unit = DateUnitMilliseconds
+		}
+	}
+
+	if unit == DateUnitMilliseconds {
+		return time.Unix(raw/1000, (raw%1000)*int64(time.Millisecond))
+	}
+	return time.Unix(raw, 0)
+}
@@ -0,0 +1,78 @@
+package agilekeychain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  int64
+		unit DateUnit
+		want time.Time
+	}{
+		{
+			name: "Seconds",
+			raw:  1362350139,
+			unit: DateUnitSeconds,
+			want: time.Unix(1362350139, 0),
+		},
+		{
+			name: "Milliseconds",
+			raw:  1362350139123,
+			unit: DateUnitMilliseconds,
+			want: time.Unix(1362350139, 123*int64(time.Millisecond)),
+		},
+		{
+			name: "Auto detects seconds",
+			raw:  1362350139,
+			unit: DateUnitAuto,
+			want: time.Unix(1362350139, 0),
+		},
+		{
+			name: "Auto detects milliseconds",
+			raw:  1362350139123,
+			unit: DateUnitAuto,
+			want: time.Unix(1362350139, 123*int64(time.Millisecond)),
+		},
+		{
+			name: "Explicit seconds overrides magnitude",
+			raw:  1362350139123,
+			unit: DateUnitSeconds,
+			want: time.Unix(1362350139123, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDate(tt.raw, tt.unit)
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAgileKeychain_DateUnit(t *testing.T) {
+	fixturePath := "../testdata/agilekeychain/example1/1Password.agilekeychain"
+
+	keychain, err := NewAgileKeychain(fixturePath, "1Password", WithDateUnit(DateUnitSeconds))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	got := keychain.contents[0].date
+	if want := time.Unix(1362350084, 0); !got.Equal(want) {
+		t.Errorf("Got wrong date: %v, want %v", got, want)
+	}
+
+	keychain, err = NewAgileKeychain(fixturePath, "1Password", WithDateUnit(DateUnitMilliseconds))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	got = keychain.contents[0].date
+	if want := time.Unix(1362350, 84*int64(time.Millisecond)); !got.Equal(want) {
+		t.Errorf("Got wrong date: %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,165 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// itemFile is the on-disk representation of a <uuid>.1password file
+type itemFile struct {
+	UUID        string
+	UpdatedAt   int64
+	CreatedAt   int64
+	TypeName    string
+	Title       string
+	Location    string
+	LocationKey string
+	KeyID       string
+	Encrypted   string
+	// how Encrypted was encrypted, see itemCiphers; empty for the usual CBC
+	Cipher       string
+	OpenContents struct {
+		SecurityLevel string
+		ContentsHash  string
+		Tags          []string
+	}
+}
+
+// item ids come from contents.js, which we don't trust, and end up in file
+// paths, so make sure they can't point outside the vault directory
+func validateItemID(id string) error {
+	if id == "" || strings.ContainsAny(id, "/\\\x00") || strings.Contains(id, "..") {
+		return fmt.Errorf("%w: %q", ErrInvalidItemID, id)
+	}
+	return nil
+}
+
+// load and parse the .1password file for the item with the given id, running
+// the open, decode and parse stages of the item pipeline
+func (k *AgileKeychain) loadItemFile(id string) (*itemFile, error) {
+	data, err := k.openItemFile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = k.decodeItemFile(id, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseItemFile(id, data)
+}
+
+// find the key an item was encrypted with, preferring the explicit key id
+// and falling back to the declared security level
+func (k *AgileKeychain) keyForItem(item *itemFile) (encryptionKey, error) {
+	encKeys, err := k.keys()
+	if err != nil {
+		return encryptionKey{}, err
+	}
+
+	if item.KeyID != "" {
+		key, ok := encKeys.keys[item.KeyID]
+		if !ok {
+			return key, fmt.Errorf("Couldn't find key with id %s for item %s", item.KeyID, item.UUID)
+		}
+		return key, nil
+	}
+
+	var key encryptionKey
+	switch item.OpenContents.SecurityLevel {
+	case "SL3":
+		key = encKeys.sl3
+	case "SL5", "":
+		key = encKeys.sl5
+	default:
+		return key, fmt.Errorf("Unknown security level %s for item %s", item.OpenContents.SecurityLevel, item.UUID)
+	}
+
+	if key.id == "" {
+		return key, fmt.Errorf("No key loaded for security level %s of item %s", item.OpenContents.SecurityLevel, item.UUID)
+	}
+	return key, nil
+}
+
+// The "encrypted" field of an item file is normally the base64 of an OpenSSL
+// salted blob, which always starts "U2FsdGVkX1".  Some exporters instead
+// store the blob itself, one byte per character, in which case the field
+// starts with the literal "Salted__" magic.  Anything else is assumed to be
+// base64.
+func decodeEncryptedPayload(encrypted string) ([]byte, error) {
+	encrypted = stripTrailingNull(encrypted)
+
+	if !strings.HasPrefix(encrypted, "Salted__") {
+		return decodeBase64(encrypted)
+	}
+
+	ret := make([]byte, 0, len(encrypted))
+	for _, r := range encrypted {
+		if r > 0xff {
+			return nil, fmt.Errorf("Invalid byte %U in raw encrypted payload", r)
+		}
+		ret = append(ret, byte(r))
+	}
+	return ret, nil
+}
+
+// decrypt the encrypted payload of an item file, returning the raw JSON
+func (k *AgileKeychain) decryptItemFile(item *itemFile) ([]byte, error) {
+	// keep Close from zeroing the key while it's in use
+	k.keyUse.RLock()
+	defer k.keyUse.RUnlock()
+
+	key, err := k.keyForItem(item)
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := decodeEncryptedPayload(item.Encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	salt, blob, err := extractSalt(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	var decrypter Decrypter = softwareDecrypter{key.key}
+	if k.decrypter != nil {
+		decrypter = k.decrypter
+	}
+
+	plaintext, err := decrypter.Decrypt(key.id, item.Cipher, salt, blob)
+	if errors.Is(err, ErrAuthenticationFailed) {
+		return nil, fmt.Errorf("%w for item %s", ErrAuthenticationFailed, item.UUID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decrypt item %s: %v", item.UUID, err)
+	}
+
+	return plaintext, nil
+}
+
+// DecryptItem decrypts the item with the given id, returning its contents
+func (k *AgileKeychain) DecryptItem(id string) (map[string]interface{}, error) {
+	item, err := k.loadItemFile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := k.decryptItemFile(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret map[string]interface{}
+	err = json.Unmarshal(plaintext, &ret)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse decrypted item %s: %v", id, err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,175 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestDecryptItem(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/example1/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	data, err := keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C")
+	if err != nil {
+		t.Fatalf("DecryptItem() error = %v", err)
+	}
+
+	if data["server"] != "ftp.dreamhost.com" {
+		t.Errorf("Got wrong server: %v", data["server"])
+	}
+	if data["username"] != "admin" {
+		t.Errorf("Got wrong username: %v", data["username"])
+	}
+
+	_, err = keychain.DecryptItem("00000000000000000000000000000000")
+	if err == nil {
+		t.Errorf("DecryptItem() of nonexistent item did not fail")
+	}
+}
+
+func TestDecryptItem_PathTraversal(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	// plant a file outside the vault directory that a malicious id could
+	// reach: data/default/../../evil.1password
+	evil := `{"uuid":"evil","keyID":"91F7E2D5E3E54447819ABDD84CFB27A2","encrypted":""}`
+	err := ioutil.WriteFile(path.Join(keychainPath, "evil.1password"), []byte(evil), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write evil item: %v", err)
+	}
+
+	contentsPath := path.Join(keychainPath, "data", "default", "contents.js")
+	contents := `[["../../evil","webforms.WebForm","Evil","",1362350139,"",0,"N"]]`
+	err = ioutil.WriteFile(contentsPath, []byte(contents), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write contents: %v", err)
+	}
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	for _, id := range []string{"../../evil", `..\..\evil`, "a/b", "", "evil\x00"} {
+		_, err = keychain.DecryptItem(id)
+		if !errors.Is(err, ErrInvalidItemID) {
+			t.Errorf("DecryptItem(%q) error = %v, want ErrInvalidItemID", id, err)
+		}
+	}
+
+	_, err = keychain.RawItemFiles()
+	if !errors.Is(err, ErrInvalidItemID) {
+		t.Errorf("RawItemFiles() error = %v, want ErrInvalidItemID", err)
+	}
+
+	_, err = keychain.FieldCounts()
+	itemErrs, ok := err.(ItemErrors)
+	if !ok || !errors.Is(itemErrs["../../evil"], ErrInvalidItemID) {
+		t.Errorf("FieldCounts() error = %v, want ErrInvalidItemID for the malicious id", err)
+	}
+}
+
+func TestDecryptItem_RawPayload(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/rawencrypted/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		id       string
+		password string
+	}{
+		{id: "B64B64B64B64B64B64B64B64B64B6401", password: "base64 secret"},
+		{id: "RAWRAWRAWRAWRAWRAWRAWRAWRAWRAW01", password: "raw secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			data, err := keychain.DecryptItem(tt.id)
+			if err != nil {
+				t.Fatalf("DecryptItem() error = %v", err)
+			}
+			if data["password"] != tt.password {
+				t.Errorf("Got wrong password: %v", data["password"])
+			}
+		})
+	}
+}
+
+func TestDecryptItem_KeyBySecurityLevel(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	// without a keyID the key is picked by security level: The Unofficial
+	// Apple Weblog declares SL3, and Tumblr declares none, meaning SL5
+	for _, id := range []string{"D8F79F17D6384808848B213EB4946ECA", "5ADFF73C09004C448D45565BC4750DE2"} {
+		itemPath := path.Join(keychainPath, "data", "default", id+".1password")
+		data, err := ioutil.ReadFile(itemPath)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		var raw map[string]interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			t.Fatalf("Failed to parse JSON: %v", err)
+		}
+		delete(raw, "keyID")
+		data, err = json.Marshal(raw)
+		if err != nil {
+			t.Fatalf("Failed to encode JSON: %v", err)
+		}
+		if err := ioutil.WriteFile(itemPath, data, 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	for id, level := range map[string]string{"D8F79F17D6384808848B213EB4946ECA": "SL3", "5ADFF73C09004C448D45565BC4750DE2": "SL5"} {
+		if _, err := keychain.DecryptItem(id); err != nil {
+			t.Errorf("DecryptItem(%s) error = %v", id, err)
+		}
+		if got, err := keychain.ItemSecurityLevel(id); err != nil || got != level {
+			t.Errorf("ItemSecurityLevel(%s) = %s, %v, want %s", id, got, err, level)
+		}
+	}
+
+	// as if only the SL5 key had been loaded
+	keychain.encKeys.sl3 = encryptionKey{}
+	if _, err := keychain.DecryptItem("D8F79F17D6384808848B213EB4946ECA"); err == nil {
+		t.Errorf("DecryptItem() of an SL3 item without an SL3 key succeeded")
+	}
+	if _, err := keychain.DecryptItem("5ADFF73C09004C448D45565BC4750DE2"); err != nil {
+		t.Errorf("DecryptItem() of an SL5 item without an SL3 key error = %v", err)
+	}
+}
+
+func TestDecodeEncryptedPayload_TrailingNULs(t *testing.T) {
+	keychainPath := rewriteHuluItem(t, func(data []byte) []byte {
+		var item map[string]interface{}
+		if err := json.Unmarshal(data, &item); err != nil {
+			t.Fatalf("Failed to parse JSON: %v", err)
+		}
+		encrypted := strings.TrimRight(item["encrypted"].(string), "\u0000=")
+		item["encrypted"] = encrypted + "\u0000\u0000\u0000"
+		out, err := json.Marshal(item)
+		if err != nil {
+			t.Fatalf("Failed to encode JSON: %v", err)
+		}
+		return out
+	})
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	if _, err := keychain.DecryptItem("13C8E12AC8E54B1F873BAB0824E521BC"); err != nil {
+		t.Errorf("DecryptItem() of item with unpadded, NUL terminated payload error = %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package agilekeychain
+
+import "fmt"
+
+// Decrypter decrypts item payloads on the keychain's behalf, for deployments
+// where the master keys must never be in this process's memory, such as
+// those keeping them in an HSM or the OS keystore.
+//
+// Decrypt is called once for each item decrypted, possibly from several
+// goroutines at once.  keyID is the Identifier, from encryptionKeys.js, of the
+// master key the item is encrypted under, picked by the item's keyID or else
+// its security level.  cipher is the item file's "cipher" field, empty for
+// AgileKeychain's usual AES-128-CBC, where the item key and IV come from the
+// master key and salt via OpenSSL's MD5 EVP_BytesToKey; see itemCiphers for
+// the names software decryption accepts.  salt and blob are the 8 bytes after
+// the payload's "Salted__" magic and everything after that.  Decrypt returns
+// the item's plaintext JSON; when a cipher that authenticates finds the
+// payload was tampered with the error should wrap ErrAuthenticationFailed.
+type Decrypter interface {
+	Decrypt(keyID string, cipher string, salt []byte, blob []byte) ([]byte, error)
+}
+
+// WithDecrypter hands item decryption to d.  The keychain then never decrypts
+// its master keys: the passphrase given to NewAgileKeychain, or to methods
+// taking one, is ignored, encryptionKeys.js is only read for its key ids and
+// levels, and nothing is validated until d first decrypts an item.  Methods
+// that need the key bytes themselves, like RelevelItem and SameMasterKey, fail.
+// Without this option items are decrypted in software with the master keys
+// decrypted from the passphrase.
+func WithDecrypter(d Decrypter) Option {
+	return func(k *AgileKeychain) {
+		k.decrypter = d
+	}
+}
+
+// the default Decrypter, using a master key held in memory
+type softwareDecrypter struct {
+	key []byte
+}
+
+func (d softwareDecrypter) Decrypt(keyID string, cipher string, salt []byte, blob []byte) ([]byte, error) {
+	decrypt, ok := itemCiphers[cipher]
+	if !ok {
+		return nil, fmt.Errorf("Unknown cipher %q", cipher)
+	}
+	return decrypt(d.key, salt, blob)
+}
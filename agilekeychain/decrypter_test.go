@@ -0,0 +1,85 @@
+package agilekeychain
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// stands in for an HSM holding the master keys
+type fakeHSM struct {
+	mu     sync.Mutex
+	keys   map[string][]byte
+	keyIDs []string
+}
+
+func (h *fakeHSM) Decrypt(keyID string, cipher string, salt []byte, blob []byte) ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.keyIDs = append(h.keyIDs, keyID)
+	key, ok := h.keys[keyID]
+	if !ok {
+		return nil, errors.New("no such key in HSM")
+	}
+	return itemCiphers[cipher](key, salt, blob)
+}
+
+func TestWithDecrypter(t *testing.T) {
+	software, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	hsm := &fakeHSM{keys: make(map[string][]byte)}
+	for id, key := range software.encKeys.keys {
+		hsm.keys[id] = key.key
+	}
+
+	// the passphrase is ignored
+	keychain, err := NewAgileKeychain(example1Path, "", WithDecrypter(hsm))
+	if err != nil {
+		t.Fatalf("NewAgileKeychain() with a Decrypter error = %v", err)
+	}
+
+	for id, key := range keychain.encKeys.keys {
+		if key.key != nil {
+			t.Errorf("Key %s decrypted despite the Decrypter", id)
+		}
+	}
+	if got := keychain.SecurityLevels(); !reflect.DeepEqual(got, []string{"SL3", "SL5"}) {
+		t.Errorf("SecurityLevels() = %v", got)
+	}
+
+	// Tumblr is SL5, The Unofficial Apple Weblog SL3
+	for _, id := range []string{"5ADFF73C09004C448D45565BC4750DE2", "D8F79F17D6384808848B213EB4946ECA"} {
+		want, err := software.DecryptItem(id)
+		if err != nil {
+			t.Fatalf("DecryptItem() error = %v", err)
+		}
+		got, err := keychain.DecryptItem(id)
+		if err != nil {
+			t.Fatalf("DecryptItem(%s) error = %v", id, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DecryptItem(%s) = %v, want %v", id, got, want)
+		}
+	}
+	wantIDs := []string{software.encKeys.sl5.id, software.encKeys.sl3.id}
+	if !reflect.DeepEqual(hsm.keyIDs, wantIDs) {
+		t.Errorf("Decrypter called with keys %v, want %v", hsm.keyIDs, wantIDs)
+	}
+
+	if err := keychain.RelevelItem("5ADFF73C09004C448D45565BC4750DE2", "SL3"); err == nil {
+		t.Errorf("RelevelItem() without master keys in memory succeeded")
+	}
+	if _, err := keychain.SameMasterKey(software); err == nil {
+		t.Errorf("SameMasterKey() without master keys in memory succeeded")
+	}
+
+	delete(hsm.keys, software.encKeys.sl5.id)
+	if _, err := keychain.DecryptItem("5ADFF73C09004C448D45565BC4750DE2"); err == nil {
+		t.Errorf("DecryptItem() succeeded though the Decrypter failed")
+	}
+}
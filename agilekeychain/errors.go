@@ -0,0 +1,80 @@
+package agilekeychain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ItemErrors collects per-item failures from operations that process every
+// item in the keychain, keyed by item id
+type ItemErrors map[string]error
+
+func (e ItemErrors) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, len(ids))
+	for ix, id := range ids {
+		msgs[ix] = fmt.Sprintf("%s: %v", id, e[id])
+	}
+
+	return fmt.Sprintf("%d items failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// ErrItemNotFound is returned when no item matches a lookup
+var ErrItemNotFound = errors.New("item not found")
+
+// ErrAmbiguousPrefix is returned when an id prefix matches more than one item
+var ErrAmbiguousPrefix = errors.New("ambiguous item id prefix")
+
+// ErrInvalidItemID is returned for item ids that aren't safe to use as a
+// file name
+var ErrInvalidItemID = errors.New("invalid item id")
+
+// ErrNoKeyringPassphrase is returned when the keyring has no passphrase for
+// the service and account given to PassphraseFromKeyring
+var ErrNoKeyringPassphrase = errors.New("no passphrase in keyring")
+
+// ErrFieldNotFound is returned when an item has no field with a given name
+var ErrFieldNotFound = errors.New("field not found")
+
+// ErrAuthenticationFailed is returned when an item encrypted with an
+// authenticated cipher fails its integrity check, meaning it was tampered
+// with or is being decrypted with the wrong key
+var ErrAuthenticationFailed = errors.New("authentication failed")
+
+// ErrWrongPassphrase is returned when a passphrase doesn't unlock the
+// keychain's encryption keys
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// ErrLocked is returned when decrypting with a keychain that has been closed
+var ErrLocked = errors.New("keychain is locked")
+
+// ErrNoPasswordStrength is returned by PasswordStrength for items without a
+// stored password strength
+var ErrNoPasswordStrength = errors.New("no password strength")
+
+// ErrNotADirectory is returned when a keychain path isn't a directory
+var ErrNotADirectory = errors.New("not a directory")
+
+// ErrKeyValidationFailed is returned when a decrypted encryption key doesn't
+// match its validation data.  When opening a keychain this almost always
+// means the passphrase was wrong, so it comes wrapped with
+// ErrWrongPassphrase.
+var ErrKeyValidationFailed = errors.New("key validation failed")
+
+// ErrMalformedContents is returned when contents.js, or an entry in it,
+// can't be parsed
+var ErrMalformedContents = errors.New("malformed contents.js")
+
+// ErrReadOnly is returned by methods that write to the keychain when it
+// wasn't opened from a directory on disk
+var ErrReadOnly = errors.New("keychain is read-only")
+
+// ErrNoTOTP is returned by TOTPQRCode for items without a TOTP seed
+var ErrNoTOTP = errors.New("no TOTP seed")
@@ -0,0 +1,116 @@
+package agilekeychain
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// decrypt one item and hand its plaintext JSON and file metadata to fn,
+// zeroing the plaintext once fn returns.  Exporters use this so that only one
+// item's secrets are held at a time.  Strings fn derives from the plaintext
+// are copies and can't be wiped; they're left to the garbage collector.
+func (k *AgileKeychain) withDecryptedItem(id string, fn func(item *itemFile, plaintext []byte) error) error {
+	item, err := k.loadItemFile(id)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := k.decryptItemFile(item)
+	if err != nil {
+		return err
+	}
+	defer clear(plaintext)
+
+	return fn(item, plaintext)
+}
+
+// ExportMacKeychain writes the logins in the keychain to w as a CSV file
+// suitable for importing into Apple Passwords / iCloud Keychain.  Items
+// without a URL are written with an empty URL column.  Items are decrypted
+// and written one at a time, so the whole vault's secrets are never in memory
+// at once.
+func (k *AgileKeychain) ExportMacKeychain(w io.Writer, passphrase string) error {
+	err := k.loadEncryptionKeys(passphrase)
+	if err != nil {
+		return err
+	}
+
+	out := csv.NewWriter(w)
+	err = out.Write([]string{"Title", "URL", "Username", "Password", "Notes", "OTPAuth"})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range k.contents {
+		if entry.entryType != webFormType {
+			continue
+		}
+
+		err = k.withDecryptedItem(entry.id, func(item *itemFile, plaintext []byte) error {
+			var data map[string]interface{}
+			err := json.Unmarshal(plaintext, &data)
+			if err != nil {
+				return fmt.Errorf("Failed to parse decrypted item %s: %v", entry.id, err)
+			}
+
+			username, password := loginCredentials(data)
+			notes, _ := data["notesPlain"].(string)
+
+			err = out.Write([]string{entry.title, item.Location, username, password, notes, ""})
+			if err != nil {
+				return err
+			}
+
+			// don't let records pile up in the csv writer's buffer
+			out.Flush()
+			return out.Error()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// turn a contents.js site, which is usually a bare domain, into a URL
+func siteURL(site string) string {
+	if strings.Contains(site, "://") {
+		return site
+	}
+	return "https://" + site
+}
+
+// ExportBookmarks writes the titles and sites of the items in the keychain to
+// w as a Netscape bookmark file, which browsers can import.  Items without a
+// site are skipped.  Nothing is decrypted.
+func (k *AgileKeychain) ExportBookmarks(w io.Writer) error {
+	_, err := io.WriteString(w, "<!DOCTYPE NETSCAPE-Bookmark-file-1>\n"+
+		"<META HTTP-EQUIV=\"Content-Type\" CONTENT=\"text/html; charset=UTF-8\">\n"+
+		"<TITLE>Bookmarks</TITLE>\n"+
+		"<H1>Bookmarks</H1>\n"+
+		"<DL><p>\n")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range k.contents {
+		if entry.site == "" || entry.entryType == tombstoneType {
+			continue
+		}
+
+		_, err = fmt.Fprintf(w, "    <DT><A HREF=\"%s\" ADD_DATE=\"%d\">%s</A>\n",
+			html.EscapeString(siteURL(entry.site)), entry.date.Unix(), html.EscapeString(entry.title))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = io.WriteString(w, "</DL><p>\n")
+	return err
+}
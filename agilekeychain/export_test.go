@@ -0,0 +1,119 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestExportMacKeychain(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/example1/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = keychain.ExportMacKeychain(&buf, "1Password")
+	if err != nil {
+		t.Fatalf("ExportMacKeychain() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse exported CSV: %v", err)
+	}
+
+	// header plus the 8 logins in the fixture
+	if len(records) != 9 {
+		t.Fatalf("Got wrong number of records: %d", len(records))
+	}
+
+	var hulu []string
+	for _, record := range records {
+		if record[0] == "Hulu" {
+			hulu = record
+		}
+	}
+
+	want := []string{"Hulu", "http://www.hulu.com/", "wendy@appleseed.com", "frirp7i1ob7wig4d", "", ""}
+	if hulu == nil {
+		t.Fatalf("Hulu login missing from export")
+	}
+	for ix := range want {
+		if hulu[ix] != want[ix] {
+			t.Errorf("Got wrong column %d for Hulu: %q, want %q", ix, hulu[ix], want[ix])
+		}
+	}
+
+	err = keychain.ExportMacKeychain(&buf, "wrong passphrase")
+	if err == nil {
+		t.Errorf("ExportMacKeychain() with wrong passphrase did not fail")
+	}
+}
+
+func TestExportBookmarks(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	// add an item whose title and site need escaping
+	appendContentsEntries(t, keychainPath,
+		`["0123456789ABCDEF0123456789ABCDEF","webforms.WebForm","Tom & Jerry's <Cartoons>","https://example.com/?a=1&b=\"2\"",1362350140,"",0,"N"]`)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = keychain.ExportBookmarks(&buf)
+	if err != nil {
+		t.Fatalf("ExportBookmarks() error = %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "<!DOCTYPE NETSCAPE-Bookmark-file-1>") {
+		t.Errorf("Missing bookmark file header")
+	}
+
+	// 8 logins with sites in the fixture plus the one added above
+	if n := strings.Count(out, "<DT>"); n != 9 {
+		t.Errorf("Got %d bookmarks, want 9", n)
+	}
+
+	wantLines := []string{
+		`<DT><A HREF="https://hulu.com" ADD_DATE="1362350139">Hulu</A>`,
+		`<DT><A HREF="https://example.com/?a=1&amp;b=&#34;2&#34;" ADD_DATE="1362350140">Tom &amp; Jerry&#39;s &lt;Cartoons&gt;</A>`,
+	}
+	for _, line := range wantLines {
+		if !strings.Contains(out, line) {
+			t.Errorf("Output missing %s", line)
+		}
+	}
+
+	if strings.Contains(out, "Chase VISA") {
+		t.Errorf("Item without a site was exported")
+	}
+}
+
+func TestWithDecryptedItem_ZeroesPlaintext(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	var kept []byte
+	err = keychain.withDecryptedItem("13C8E12AC8E54B1F873BAB0824E521BC", func(item *itemFile, plaintext []byte) error {
+		if !bytes.Contains(plaintext, []byte("frirp7i1ob7wig4d")) {
+			t.Errorf("Plaintext doesn't contain the password: %s", plaintext)
+		}
+		kept = plaintext
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withDecryptedItem() error = %v", err)
+	}
+
+	if len(kept) == 0 || len(bytes.Trim(kept, "\x00")) != 0 {
+		t.Errorf("Plaintext not zeroed after use: %q", kept)
+	}
+}
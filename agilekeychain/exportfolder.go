@@ -0,0 +1,265 @@
+package agilekeychain
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// length of the master keys 1Password generates
+const masterKeyLength = 1024
+
+// ExportFolder writes the items in the folder with the given id, including
+// those in folders nested inside it and the folders themselves, to a new
+// keychain at destPath, which must not already exist.
+//
+// The new keychain gets freshly generated SL3 and SL5 keys, encrypted under
+// passphrase with minRecommendedIterations PBKDF2 iterations, and each item
+// is re-encrypted with the new key of its level; nothing encrypted under this
+// keychain's keys is copied over.  The keychain must be unlocked.  Items'
+// contents.js entries and unencrypted metadata are copied unchanged.
+// Attachments aren't copied, as for ExportPIFBundle.  Deleted items aren't
+// exported.
+func (k *AgileKeychain) ExportFolder(folderID string, destPath string, passphrase string) error {
+	folder, err := k.GetItem(folderID)
+	if err != nil {
+		return err
+	}
+	if folder.ItemType() != ItemTypeFolder {
+		return fmt.Errorf("Item %s is not a folder", folderID)
+	}
+
+	return k.exportKeychain(k.folderMembers(folderID), destPath, passphrase)
+}
+
+// write the items with the given ids to a new keychain at destPath, as
+// described for ExportFolder
+func (k *AgileKeychain) exportKeychain(members map[string]bool, destPath string, passphrase string) error {
+	newKeys := make(map[securityLevel]encryptionKey)
+	var rawKeys rawEncryptionKeys
+	for _, level := range []securityLevel{securityLevel3, securityLevel5} {
+		key, raw, err := generateEncryptionKey(level, passphrase, minRecommendedIterations)
+		if err != nil {
+			return err
+		}
+		newKeys[level] = key
+		rawKeys.List = append(rawKeys.List, raw)
+	}
+	rawKeys.SL3 = newKeys[securityLevel3].id
+	rawKeys.SL5 = newKeys[securityLevel5].id
+
+	dataDir := path.Join(destPath, "data", defaultVault)
+	err := os.Mkdir(destPath, 0700)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(dataDir, 0700)
+	if err != nil {
+		return err
+	}
+
+	keysJSON, err := marshalEncryptionKeys(rawKeys)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(path.Join(dataDir, "encryptionKeys.js"), keysJSON, 0600)
+	if err != nil {
+		return err
+	}
+
+	contents, err := k.folderContentsJSON(members)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(path.Join(dataDir, "contents.js"), contents, 0600)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range k.contents {
+		if !members[entry.id] {
+			continue
+		}
+
+		err = k.exportItemFile(entry.id, dataDir, newKeys)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// the ids of the folder with the given id and of every item and folder in it,
+// however deeply nested
+func (k *AgileKeychain) folderMembers(folderID string) map[string]bool {
+	members := map[string]bool{folderID: true}
+
+	for added := true; added; {
+		added = false
+		for _, entry := range k.contents {
+			if entry.entryType == tombstoneType || members[entry.id] || !members[entry.folderID] {
+				continue
+			}
+			members[entry.id] = true
+			added = true
+		}
+	}
+	return members
+}
+
+// the entries of contents.js for the given items, copied byte for byte
+func (k *AgileKeychain) folderContentsJSON(members map[string]bool) ([]byte, error) {
+	data, err := k.readFile(path.Join(k.dataDir(), "contents.js"))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []json.RawMessage
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		relaxedErr := json.Unmarshal(relaxJSON(data), &entries)
+		if relaxedErr != nil {
+			return nil, err
+		}
+	}
+
+	var kept []string
+	for _, entry := range entries {
+		var fields []json.RawMessage
+		var id string
+		if json.Unmarshal(entry, &fields) != nil || len(fields) == 0 || json.Unmarshal(fields[0], &id) != nil {
+			continue
+		}
+		if members[id] {
+			kept = append(kept, string(entry))
+		}
+	}
+
+	return []byte("[" + strings.Join(kept, ",") + "]"), nil
+}
+
+// decrypt an item and write it to dataDir re-encrypted with the key in
+// newKeys of the same level
+func (k *AgileKeychain) exportItemFile(id string, dataDir string, newKeys map[securityLevel]encryptionKey) error {
+	item, err := k.loadItemFile(id)
+	if err != nil {
+		return err
+	}
+
+	oldKey, err := k.keyForItem(item)
+	if err != nil {
+		return err
+	}
+	newKey := newKeys[oldKey.level]
+
+	plaintext, err := k.decryptItemFile(item)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for ix := range plaintext {
+			plaintext[ix] = 0
+		}
+	}()
+
+	encrypted, err := encryptItemPayload(newKey.key, plaintext)
+	if err != nil {
+		return err
+	}
+
+	raw, err := k.loadRawItemFile(id)
+	if err != nil {
+		return err
+	}
+	raw["keyID"] = newKey.id
+	raw["encrypted"] = encrypted
+	// encryptItemPayload only does CBC
+	delete(raw, "cipher")
+
+	out, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path.Join(dataDir, id+".1password"), out, 0600)
+}
+
+// generate a random master key for level, and its encryptionKeys.js entry
+// with the key encrypted under passphrase
+func generateEncryptionKey(level securityLevel, passphrase string, iterations int) (encryptionKey, rawEncryptionKey, error) {
+	var key encryptionKey
+	var raw rawEncryptionKey
+
+	idBytes := make([]byte, 16)
+	_, err := rand.Read(idBytes)
+	if err != nil {
+		return key, raw, err
+	}
+
+	key.id = strings.ToUpper(hex.EncodeToString(idBytes))
+	key.level = level
+	key.iterations = iterations
+	key.key = make([]byte, masterKeyLength)
+	_, err = rand.Read(key.key)
+	if err != nil {
+		return key, raw, err
+	}
+
+	salt := make([]byte, 8)
+	_, err = rand.Read(salt)
+	if err != nil {
+		return key, raw, err
+	}
+
+	derivedKey := pbkdf2.Key([]byte(passphrase), salt, iterations, 32, sha1.New)
+	blob, err := cbcEncrypt(key.key, derivedKey[0:16], derivedKey[16:32])
+	if err != nil {
+		return key, raw, err
+	}
+	salted := append(append([]byte("Salted__"), salt...), blob...)
+
+	// the validation blob is the key encrypted with itself, as validateKey
+	// expects with ValidationKDFOpenSSL
+	validation, err := encryptItemPayload(key.key, key.key)
+	if err != nil {
+		return key, raw, err
+	}
+
+	raw = rawEncryptionKey{
+		Data:       base64.StdEncoding.EncodeToString(salted) + "\u0000",
+		Validation: validation,
+		Level:      level.String(),
+		Identifier: key.id,
+		Iterations: iterations,
+	}
+	return key, raw, nil
+}
+
+// encode keys as encryptionKeys.js, with the lower-case names 1Password uses
+func marshalEncryptionKeys(keys rawEncryptionKeys) ([]byte, error) {
+	list := make([]map[string]interface{}, len(keys.List))
+	for ix, key := range keys.List {
+		list[ix] = map[string]interface{}{
+			"data":       key.Data,
+			"validation": key.Validation,
+			"level":      key.Level,
+			"identifier": key.Identifier,
+			"iterations": key.Iterations,
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"SL3":  keys.SL3,
+		"SL5":  keys.SL5,
+		"list": list,
+	})
+}
@@ -0,0 +1,163 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const (
+	workFolderID = "F01DE700F01DE700F01DE700F01DE700"
+	subFolderID  = "F01DE700F01DE700F01DE700F01DE701"
+)
+
+// file the fixture's Apple Weblog (SL3) and Tumblr (SL5) logins in a Work
+// folder, and its FTP account in a folder inside that
+func makeFolders(t *testing.T, keychainPath string) {
+	t.Helper()
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	contentsPath := path.Join(keychainPath, "data", "default", "contents.js")
+	data, err := ioutil.ReadFile(contentsPath)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var entries [][]interface{}
+	if err := decoder.Decode(&entries); err != nil {
+		t.Fatalf("Failed to parse contents: %v", err)
+	}
+
+	folderOf := map[string]string{
+		"D8F79F17D6384808848B213EB4946ECA": workFolderID,
+		"5ADFF73C09004C448D45565BC4750DE2": workFolderID,
+		"4E36C011EE8348B1B24418218B04018C": subFolderID,
+	}
+	for _, entry := range entries {
+		if folder, ok := folderOf[entry[0].(string)]; ok {
+			entry[5] = folder
+		}
+	}
+
+	for id, parent := range map[string]string{workFolderID: "", subFolderID: workFolderID} {
+		entries = append(entries, []interface{}{id, "system.folder.Regular", "Folder " + id, "", 1362350139, parent, 0, "N"})
+
+		encrypted, err := encryptItemPayload(keychain.encKeys.sl5.key, []byte("{}"))
+		if err != nil {
+			t.Fatalf("encryptItemPayload() error = %v", err)
+		}
+		itemJSON, err := json.Marshal(map[string]interface{}{
+			"uuid":      id,
+			"typeName":  "system.folder.Regular",
+			"title":     "Folder " + id,
+			"keyID":     keychain.encKeys.sl5.id,
+			"encrypted": encrypted,
+		})
+		if err != nil {
+			t.Fatalf("Failed to encode JSON: %v", err)
+		}
+		if err := ioutil.WriteFile(path.Join(keychainPath, "data", "default", id+".1password"), itemJSON, 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	data, err = json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+	if err := ioutil.WriteFile(contentsPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
+
+func TestExportFolder(t *testing.T) {
+	keychainPath := copyFixture(t)
+	makeFolders(t, keychainPath)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	destPath := path.Join(t.TempDir(), "Work.agilekeychain")
+	if err := keychain.ExportFolder(workFolderID, destPath, "n3w passphrase"); err != nil {
+		t.Fatalf("ExportFolder() error = %v", err)
+	}
+
+	exported, err := NewAgileKeychain(destPath, "n3w passphrase")
+	if err != nil {
+		t.Fatalf("Error opening exported keychain: %v", err)
+	}
+
+	var ids []string
+	for _, entry := range exported.contents {
+		ids = append(ids, entry.id)
+	}
+	sort.Strings(ids)
+	wantIDs := []string{
+		"4E36C011EE8348B1B24418218B04018C",
+		"5ADFF73C09004C448D45565BC4750DE2",
+		"D8F79F17D6384808848B213EB4946ECA",
+		workFolderID,
+		subFolderID,
+	}
+	if !reflect.DeepEqual(ids, wantIDs) {
+		t.Errorf("Exported items = %v, want %v", ids, wantIDs)
+	}
+
+	for _, id := range wantIDs {
+		want, err := keychain.DecryptItem(id)
+		if err != nil {
+			t.Fatalf("DecryptItem() error = %v", err)
+		}
+		got, err := exported.DecryptItem(id)
+		if err != nil {
+			t.Errorf("DecryptItem(%s) on exported keychain error = %v", id, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Exported item %s = %v, want %v", id, got, want)
+		}
+
+		gotLevel, _ := exported.ItemSecurityLevel(id)
+		wantLevel, _ := keychain.ItemSecurityLevel(id)
+		if gotLevel != wantLevel {
+			t.Errorf("Exported item %s is %s, want %s", id, gotLevel, wantLevel)
+		}
+	}
+
+	for _, id := range exported.KeyIdentifiers() {
+		for _, oldID := range keychain.KeyIdentifiers() {
+			if id == oldID {
+				t.Errorf("Exported keychain reuses key %s", id)
+			}
+		}
+	}
+	if missing := exported.ItemsWithMissingFolder(); len(missing) != 0 {
+		t.Errorf("Exported keychain has items in missing folders: %v", missing)
+	}
+
+	if _, err := NewAgileKeychain(destPath, "1Password"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Opening exported keychain with the old passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+
+	if err := keychain.ExportFolder(workFolderID, destPath, "n3w passphrase"); err == nil {
+		t.Errorf("ExportFolder() to an existing path succeeded")
+	}
+	if err := keychain.ExportFolder("5ADFF73C09004C448D45565BC4750DE2", path.Join(t.TempDir(), "x"), "p"); err == nil {
+		t.Errorf("ExportFolder() of a login succeeded")
+	}
+	if err := keychain.ExportFolder("00000000000000000000000000000000", path.Join(t.TempDir(), "x"), "p"); !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("ExportFolder() of unknown id error = %v, want ErrItemNotFound", err)
+	}
+}
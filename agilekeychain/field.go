@@ -0,0 +1,124 @@
+package agilekeychain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetField decrypts the item with the given id and returns the value of its
+// field called name, compared case-insensitively.  Login form fields match
+// on their designation ("username", "password") or their name, section
+// fields on their title or name, and for other items each top-level key is
+// a field, e.g. "reg_code" on a software license.  Returns ErrFieldNotFound
+// if nothing matches.
+func (k *AgileKeychain) GetField(id string, name string) (string, error) {
+	_, err := k.GetItem(id)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := k.DecryptItem(id)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := findField(data, name)
+	if !ok {
+		return "", fmt.Errorf("%w: %q in item %s", ErrFieldNotFound, name, id)
+	}
+	return value, nil
+}
+
+// look a field up in a decrypted item, trying login fields, then section
+// fields, then top-level keys
+func findField(data map[string]interface{}, name string) (string, bool) {
+	for _, field := range parseLoginFields(data) {
+		if strings.EqualFold(field.Designation, name) || strings.EqualFold(field.Name, name) {
+			return field.Value, true
+		}
+	}
+
+	sections, _ := data["sections"].([]interface{})
+	for _, rawSection := range sections {
+		section, ok := rawSection.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sectionFields, _ := section["fields"].([]interface{})
+		for _, rawField := range sectionFields {
+			field, ok := rawField.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			title, _ := field["t"].(string)
+			fieldName, _ := field["n"].(string)
+			if strings.EqualFold(title, name) || strings.EqualFold(fieldName, name) {
+				return scalarString(field["v"])
+			}
+		}
+	}
+
+	for key, value := range data {
+		if key == "fields" || key == "sections" || !strings.EqualFold(key, name) {
+			continue
+		}
+		if s, ok := scalarString(value); ok {
+			return s, true
+		}
+	}
+
+	return "", false
+}
+
+// the values of every field in a decrypted item that findField could find
+func fieldValues(data map[string]interface{}) []string {
+	var ret []string
+	for _, field := range parseLoginFields(data) {
+		ret = append(ret, field.Value)
+	}
+
+	sections, _ := data["sections"].([]interface{})
+	for _, rawSection := range sections {
+		section, ok := rawSection.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sectionFields, _ := section["fields"].([]interface{})
+		for _, rawField := range sectionFields {
+			field, ok := rawField.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if value, ok := scalarString(field["v"]); ok {
+				ret = append(ret, value)
+			}
+		}
+	}
+
+	for key, value := range data {
+		if key == "fields" || key == "sections" {
+			continue
+		}
+		if s, ok := scalarString(value); ok {
+			ret = append(ret, s)
+		}
+	}
+
+	return ret
+}
+
+// format a JSON string, number or bool as a string; anything else isn't a
+// field value
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64, bool:
+		return fmt.Sprint(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,73 @@
+package agilekeychain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetField(t *testing.T) {
+	fixturePath := "../testdata/agilekeychain/example1/1Password.agilekeychain"
+
+	keychain, err := NewAgileKeychain(fixturePath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		field   string
+		want    string
+		wantErr error
+	}{
+		{"Login designation", "468B1E24F93B413DAD57ABE6F1C01DF6", "Username", "wendy@appleseed.com", nil},
+		{"Login name", "468B1E24F93B413DAD57ABE6F1C01DF6", "EMAIL", "wendy@appleseed.com", nil},
+		{"Login password", "468B1E24F93B413DAD57ABE6F1C01DF6", "password", "vet4juf4nim1ow6ay2ph", nil},
+		{"Top-level key", "F78CEC04078743B6975511A6FDDBED7E", "reg_code", "1PW3-0000-000000-0000", nil},
+		{"Numeric value", "E482B70C038D4DD78A0940728FA737BF", "expiry_mm", "5", nil},
+		{"Missing field", "F78CEC04078743B6975511A6FDDBED7E", "API key", "", ErrFieldNotFound},
+		{"Missing item", "0123456789ABCDEF0123456789ABCDEF", "password", "", ErrItemNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := keychain.GetField(tt.id, tt.field)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetField() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetField() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetField() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindField_Sections(t *testing.T) {
+	data := map[string]interface{}{
+		"sections": []interface{}{
+			map[string]interface{}{
+				"title": "Server",
+				"fields": []interface{}{
+					map[string]interface{}{"t": "API key", "n": "api_key", "k": "concealed", "v": "s3cret"},
+					map[string]interface{}{"t": "Port", "n": "port", "k": "string", "v": float64(8443)},
+				},
+			},
+		},
+	}
+
+	for name, want := range map[string]string{"api key": "s3cret", "API_KEY": "s3cret", "port": "8443"} {
+		got, ok := findField(data, name)
+		if !ok || got != want {
+			t.Errorf("findField(%q) = %q, %v, want %q", name, got, ok, want)
+		}
+	}
+
+	if _, ok := findField(data, "sections"); ok {
+		t.Errorf("findField() matched the sections array itself")
+	}
+}
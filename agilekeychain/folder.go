@@ -0,0 +1,26 @@
+package agilekeychain
+
+// ItemsWithMissingFolder returns the items whose FolderID doesn't match any
+// folder in the keychain, in contents.js order, such as can be left behind by
+// a partial sync.  Returns an empty slice if every folder reference resolves.
+// Only contents.js is consulted.
+func (k *AgileKeychain) ItemsWithMissingFolder() []Item {
+	folders := make(map[string]bool)
+	for _, entry := range k.contents {
+		if ParseItemType(entry.entryType) == ItemTypeFolder {
+			folders[entry.id] = true
+		}
+	}
+
+	ret := []Item{}
+	for _, entry := range k.contents {
+		if entry.folderID == "" || entry.entryType == tombstoneType {
+			continue
+		}
+
+		if !folders[entry.folderID] {
+			ret = append(ret, entry.item())
+		}
+	}
+	return ret
+}
@@ -0,0 +1,31 @@
+package agilekeychain
+
+import (
+	"testing"
+)
+
+func TestItemsWithMissingFolder(t *testing.T) {
+	keychainPath := copyFixture(t)
+	appendContentsEntries(t, keychainPath,
+		`["F01DE700F01DE700F01DE700F01DE700","system.folder.Regular","Work","",1362350139,"",0,"N"]`,
+		`["0123456789ABCDEF0123456789ABCDE1","webforms.WebForm","Filed","",1362350139,"F01DE700F01DE700F01DE700F01DE700",0,"N"]`,
+		`["0123456789ABCDEF0123456789ABCDE2","webforms.WebForm","Dangling","",1362350139,"F01DE700F01DE700F01DE700F01DEAD",0,"N"]`)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	got := keychain.ItemsWithMissingFolder()
+	if len(got) != 1 || got[0].ID != "0123456789ABCDEF0123456789ABCDE2" || got[0].FolderID != "F01DE700F01DE700F01DE700F01DEAD" {
+		t.Errorf("ItemsWithMissingFolder() = %+v, want just the dangling item", got)
+	}
+
+	pristine, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	if got := pristine.ItemsWithMissingFolder(); got == nil || len(got) != 0 {
+		t.Errorf("ItemsWithMissingFolder() on pristine fixture = %#v, want empty slice", got)
+	}
+}
@@ -0,0 +1,196 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// shown in place of passwords and other concealed values; fixed length so as
+// not to give away the length of the secret
+const maskedValue = "********"
+
+type formatOptions struct {
+	revealPasswords bool
+}
+
+// FormatOption configures FormatItem
+type FormatOption func(*formatOptions)
+
+// RevealPasswords makes FormatItem print passwords and other concealed
+// fields instead of masking them
+func RevealPasswords() FormatOption {
+	return func(o *formatOptions) {
+		o.revealPasswords = true
+	}
+}
+
+// a labeled value in a formatted item
+type formattedField struct {
+	label     string
+	value     string
+	concealed bool
+}
+
+// FormatItem decrypts the item with the given id and writes a labeled,
+// human-readable rendering of it to w: title, username, password, URL, any
+// other fields, and notes.  Passwords and other concealed fields are masked
+// unless RevealPasswords is given.
+func (k *AgileKeychain) FormatItem(id string, w io.Writer, passphrase string, opts ...FormatOption) error {
+	var options formatOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	entry, err := k.GetItem(id)
+	if err != nil {
+		return err
+	}
+
+	err = k.loadEncryptionKeys(passphrase)
+	if err != nil {
+		return err
+	}
+
+	return k.withDecryptedItem(id, func(item *itemFile, plaintext []byte) error {
+		var data map[string]interface{}
+		err := json.Unmarshal(plaintext, &data)
+		if err != nil {
+			return fmt.Errorf("Failed to parse decrypted item %s: %v", id, err)
+		}
+
+		username, password := loginCredentials(data)
+		if entry.Type != webFormType {
+			username, _ = data["username"].(string)
+			password, _ = data["password"].(string)
+		}
+
+		url := item.Location
+		if url == "" && entry.Site != "" {
+			url = siteURL(entry.Site)
+		}
+
+		fields := []formattedField{
+			{label: "Title", value: entry.Title},
+			{label: "Username", value: username},
+			{label: "Password", value: password, concealed: true},
+			{label: "URL", value: url},
+		}
+		fields = append(fields, customFields(data)...)
+
+		for _, field := range fields {
+			if field.value == "" {
+				continue
+			}
+
+			value := field.value
+			if field.concealed && !options.revealPasswords {
+				value = maskedValue
+			}
+
+			_, err = fmt.Fprintf(w, "%s: %s\n", field.label, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		notes, _ := data["notesPlain"].(string)
+		if notes != "" {
+			_, err = fmt.Fprintf(w, "\nNotes:\n%s\n", strings.TrimRight(notes, "\n"))
+		}
+		return err
+	})
+}
+
+// top-level keys of older item types that are known not to hold secrets.
+// Every other top-level value is concealed, since keys like "cvv", "ccnum"
+// and "pin" are only recognisable by name.
+var plainTopLevelKeys = map[string]bool{
+	// databases and servers
+	"database": true, "database_type": true, "hostname": true, "port": true,
+	"sid": true, "alias": true, "url": true, "admin_console_url": true,
+	// identities
+	"firstname": true, "initial": true, "lastname": true, "sex": true,
+	"company": true, "department": true, "jobtitle": true, "occupation": true,
+	"address1": true, "address2": true, "city": true, "state": true,
+	"zip": true, "country": true, "email": true, "website": true,
+	// credit cards and bank accounts
+	"cardholder": true, "type": true, "expiry_mm": true, "expiry_yy": true,
+	"bank": true, "bankName": true, "owner": true, "accountType": true,
+	// email accounts and wireless routers
+	"pop_type": true, "pop_server": true, "pop_port": true,
+	"pop_username": true, "smtp_server": true, "smtp_port": true,
+	"smtp_username": true, "provider": true, "network_name": true,
+	"wireless_security": true,
+	// software licenses
+	"product_version": true, "reg_name": true, "reg_email": true,
+	"publisher_name": true, "publisher_website": true, "order_date": true,
+}
+
+// the fields of a decrypted item other than the username, password and notes:
+// undesignated login form fields, section fields, or for older item types
+// their top-level keys, which are concealed unless they're in
+// plainTopLevelKeys
+func customFields(data map[string]interface{}) []formattedField {
+	var ret []formattedField
+
+	_, hasFields := data["fields"]
+	_, hasSections := data["sections"]
+
+	for _, field := range parseLoginFields(data) {
+		// buttons and the like have no value worth showing
+		if field.Designation != "" || field.Type == "B" || field.Type == "I" {
+			continue
+		}
+		ret = append(ret, formattedField{label: field.Name, value: field.Value, concealed: field.Type == "P"})
+	}
+
+	sections, _ := data["sections"].([]interface{})
+	for _, rawSection := range sections {
+		section, ok := rawSection.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sectionFields, _ := section["fields"].([]interface{})
+		for _, rawField := range sectionFields {
+			field, ok := rawField.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			label, _ := field["t"].(string)
+			if label == "" {
+				label, _ = field["n"].(string)
+			}
+			value, _ := scalarString(field["v"])
+			kind, _ := field["k"].(string)
+			ret = append(ret, formattedField{label: label, value: value, concealed: kind == "concealed"})
+		}
+	}
+
+	if hasFields || hasSections {
+		return ret
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		switch key {
+		case "username", "password", "notesPlain":
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := scalarString(data[key])
+		if !ok {
+			continue
+		}
+		ret = append(ret, formattedField{label: key, value: value, concealed: !plainTopLevelKeys[key]})
+	}
+	return ret
+}
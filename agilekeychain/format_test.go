@@ -0,0 +1,111 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestFormatItem(t *testing.T) {
+	fixturePath := "../testdata/agilekeychain/example1/1Password.agilekeychain"
+
+	keychain, err := NewAgileKeychain(fixturePath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		opts []FormatOption
+		want string
+	}{
+		{
+			name: "Login, masked",
+			id:   "13C8E12AC8E54B1F873BAB0824E521BC",
+			want: "Title: Hulu\n" +
+				"Username: wendy@appleseed.com\n" +
+				"Password: ********\n" +
+				"URL: http://www.hulu.com/\n" +
+				"stayloggedin: ✓\n",
+		},
+		{
+			name: "Login, revealed",
+			id:   "13C8E12AC8E54B1F873BAB0824E521BC",
+			opts: []FormatOption{RevealPasswords()},
+			want: "Title: Hulu\n" +
+				"Username: wendy@appleseed.com\n" +
+				"Password: frirp7i1ob7wig4d\n" +
+				"URL: http://www.hulu.com/\n" +
+				"stayloggedin: ✓\n",
+		},
+		{
+			name: "Database with notes",
+			id:   "27DCFA2810B24083A3ECC7CEABC7C0A9",
+			want: "Title: Orders\n" +
+				"Username: orders_app\n" +
+				"Password: ********\n" +
+				"database: orders_production\n" +
+				"database_type: mysql\n" +
+				"hostname: 10.0.1.50\n" +
+				"port: 3066\n" +
+				"\nNotes:\nSample database account.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := keychain.FormatItem(tt.id, &buf, "1Password", tt.opts...)
+			if err != nil {
+				t.Fatalf("FormatItem() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("FormatItem() =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomFields_ConcealedSection(t *testing.T) {
+	data := map[string]interface{}{
+		"sections": []interface{}{
+			map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{"t": "API key", "k": "concealed", "v": "s3cret"},
+					map[string]interface{}{"n": "region", "k": "string", "v": "eu"},
+				},
+			},
+		},
+	}
+
+	got := customFields(data)
+	want := []formattedField{
+		{label: "API key", value: "s3cret", concealed: true},
+		{label: "region", value: "eu"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("customFields() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCustomFields_TopLevelKeys(t *testing.T) {
+	data := map[string]interface{}{
+		"cardholder": "Wendy Appleseed",
+		"ccnum":      "4111111111111111",
+		"cvv":        "123",
+		"pin":        "9876",
+		"expiry_mm":  "12",
+	}
+
+	got := customFields(data)
+	want := []formattedField{
+		{label: "cardholder", value: "Wendy Appleseed"},
+		{label: "ccnum", value: "4111111111111111", concealed: true},
+		{label: "cvv", value: "123", concealed: true},
+		{label: "expiry_mm", value: "12"},
+		{label: "pin", value: "9876", concealed: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("customFields() = %+v, want %+v", got, want)
+	}
+}
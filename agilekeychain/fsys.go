@@ -0,0 +1,55 @@
+package agilekeychain
+
+import (
+	"io/fs"
+	"os"
+)
+
+// NewAgileKeychainFS creates an AgileKeychain from the keychain at the root
+// of fsys, which holds the same files as a 1Password.agilekeychain directory
+// (data/default/contents.js and so on).  This lets keychains be read from an
+// embed.FS, a zip file, or a fstest.MapFS built in a test, without touching
+// the disk.  Otherwise it behaves as NewAgileKeychain.
+//
+// fsys is read through as needed, not copied, including by methods that list
+// or stat files such as OrphanedItemFiles and DumpIndex.  The keychain is
+// read-only: RelevelItem fails with ErrReadOnly.  WithSharedRead, WithIndex
+// and WithBaseDir have no effect.
+func NewAgileKeychainFS(fsys fs.FS, passphrase string, opts ...Option) (*AgileKeychain, error) {
+	ret := &AgileKeychain{}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	ret.sharedRead = false
+	ret.index = nil
+	ret.fsys = fsys
+	ret.readOnly = true
+
+	if ret.vault != "" {
+		err := validateVaultName(ret.vault)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := ret.loadContents()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ret.unlock(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// the keychain directory as an fs.FS, for reading files other than those
+// readFile reads
+func (k *AgileKeychain) dirFS() fs.FS {
+	if k.fsys != nil {
+		return k.fsys
+	}
+	return os.DirFS(k.baseDir)
+}
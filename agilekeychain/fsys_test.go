@@ -0,0 +1,129 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestNewAgileKeychainFS(t *testing.T) {
+	keychain, err := NewAgileKeychainFS(os.DirFS(example1Path), "1Password")
+	if err != nil {
+		t.Fatalf("NewAgileKeychainFS() error = %v", err)
+	}
+
+	onDisk, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	if got, want := keychain.List(), onDisk.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %+v, want %+v", got, want)
+	}
+
+	fields, err := keychain.GetLoginFields("5ADFF73C09004C448D45565BC4750DE2")
+	if err != nil {
+		t.Fatalf("GetLoginFields() error = %v", err)
+	}
+	if len(fields) < 2 || fields[1].Value != "vow6wem2wo" {
+		t.Errorf("GetLoginFields() = %+v", fields)
+	}
+
+	if got := keychain.Vaults(); !reflect.DeepEqual(got, []string{"default"}) {
+		t.Errorf("Vaults() = %q, want [default]", got)
+	}
+}
+
+func TestNewAgileKeychainFS_MalformedContents(t *testing.T) {
+	keys, err := ioutil.ReadFile(path.Join(example1Path, "data", "default", "encryptionKeys.js"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		contents string
+		wantLen  int
+		wantErr  bool
+	}{
+		{"Empty", `[]`, 0, false},
+		{"One entry", `[["A","webforms.WebForm","One","",0,"",0,"N"]]`, 1, false},
+		{"Not JSON", `[[`, 0, true},
+		{"Not an array", `{"A": 1}`, 0, true},
+		{"Short entry", `[["A","webforms.WebForm"]]`, 0, true},
+		{"Wrong element type", `[["A","webforms.WebForm","One","","yesterday","",0,"N"]]`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"data/default/contents.js":       {Data: []byte(tt.contents)},
+				"data/default/encryptionKeys.js": {Data: keys},
+			}
+
+			keychain, err := NewAgileKeychainFS(fsys, "1Password")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAgileKeychainFS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(keychain.List()) != tt.wantLen {
+				t.Errorf("List() = %+v, want %d items", keychain.List(), tt.wantLen)
+			}
+		})
+	}
+
+	_, err = NewAgileKeychainFS(fstest.MapFS{"data/default/contents.js": {Data: []byte(`[]`)}}, "1Password")
+	if err == nil {
+		t.Error("NewAgileKeychainFS() without encryptionKeys.js error = nil, want error")
+	}
+}
+
+func TestNewAgileKeychainFS_MapFS(t *testing.T) {
+	// a copy of the fixture in memory, with one item file contents.js
+	// doesn't list
+	fsys := fstest.MapFS{}
+	err := fs.WalkDir(os.DirFS(example1Path), ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path.Join(example1Path, name))
+		fsys[name] = &fstest.MapFile{Data: data, ModTime: time.Unix(1362350139, 0)}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	const orphan = "data/default/0123456789ABCDEF0123456789ABCDEF.1password"
+	fsys[orphan] = fsys["data/default/5ADFF73C09004C448D45565BC4750DE2.1password"]
+
+	keychain, err := NewAgileKeychainFS(fsys, "1Password")
+	if err != nil {
+		t.Fatalf("NewAgileKeychainFS() error = %v", err)
+	}
+
+	if err := keychain.SelfTest("1Password"); err != nil {
+		t.Errorf("SelfTest() error = %v", err)
+	}
+
+	orphans, err := keychain.OrphanedItemFiles()
+	if err != nil || !reflect.DeepEqual(orphans, []string{orphan}) {
+		t.Errorf("OrphanedItemFiles() = %q, %v, want [%s]", orphans, err, orphan)
+	}
+
+	var index bytes.Buffer
+	if err := keychain.DumpIndex(&index); err != nil {
+		t.Fatalf("DumpIndex() error = %v", err)
+	}
+	if err := keychain.LoadIndex(&index); err != nil {
+		t.Errorf("LoadIndex() error = %v", err)
+	}
+
+	err = keychain.RelevelItem("5ADFF73C09004C448D45565BC4750DE2", "SL3")
+	if !errors.Is(err, ErrReadOnly) {
+		t.Errorf("RelevelItem() error = %v, want ErrReadOnly", err)
+	}
+}
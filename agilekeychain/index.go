@@ -0,0 +1,122 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"time"
+)
+
+// bump whenever indexFile or indexEntry change shape
+const indexVersion = 4
+
+// indexFile is the serialized form of the parsed contents.js, along with
+// enough about the file it came from to tell whether it's stale
+type indexFile struct {
+	Version         int
+	ContentsModTime time.Time
+	ContentsSize    int64
+	Entries         []indexEntry
+}
+
+type indexEntry struct {
+	ID               string
+	Type             string
+	Title            string
+	Site             string
+	Date             time.Time
+	FolderID         string
+	PasswordStrength int
+	Trashed          bool
+}
+
+// WithIndex makes NewAgileKeychain restore the parsed contents from an index
+// written by DumpIndex instead of parsing contents.js, as long as the index is
+// still current (see LoadIndex)
+func WithIndex(r io.Reader) Option {
+	return func(k *AgileKeychain) {
+		k.index = r
+	}
+}
+
+func (k *AgileKeychain) contentsFileInfo() (os.FileInfo, error) {
+	if k.itemOpener != nil {
+		return nil, errNoDirectory("stat contents.js")
+	}
+	return fs.Stat(k.dirFS(), path.Join(k.vaultDir(), "contents.js"))
+}
+
+// DumpIndex writes the parsed contents of the keychain to w, so that a later
+// LoadIndex (or WithIndex) can skip parsing contents.js.  The index holds
+// only the unencrypted metadata that contents.js does.
+func (k *AgileKeychain) DumpIndex(w io.Writer) error {
+	info, err := k.contentsFileInfo()
+	if err != nil {
+		return err
+	}
+
+	index := indexFile{
+		Version:         indexVersion,
+		ContentsModTime: info.ModTime(),
+		ContentsSize:    info.Size(),
+		Entries:         make([]indexEntry, len(k.contents)),
+	}
+
+	for ix, e := range k.contents {
+		index.Entries[ix] = indexEntry{
+			ID:               e.id,
+			Type:             e.entryType,
+			Title:            e.title,
+			Site:             e.site,
+			Date:             e.date,
+			FolderID:         e.folderID,
+			PasswordStrength: e.passwordStrength,
+			Trashed:          e.trashed,
+		}
+	}
+
+	return json.NewEncoder(w).Encode(index)
+}
+
+// LoadIndex restores the parsed contents of the keychain from an index written
+// by DumpIndex.  The index is only trusted if contents.js has the same
+// modification time and size as when the index was written; otherwise, or if
+// the index can't be read, contents.js is parsed as usual.
+func (k *AgileKeychain) LoadIndex(r io.Reader) error {
+	info, err := k.contentsFileInfo()
+	if err != nil {
+		return err
+	}
+
+	var index indexFile
+	err = json.NewDecoder(r).Decode(&index)
+	if err != nil || index.Version != indexVersion ||
+		!index.ContentsModTime.Equal(info.ModTime()) || index.ContentsSize != info.Size() {
+		return k.loadContents()
+	}
+
+	contents := make(keychainContents, len(index.Entries))
+	for ix, e := range index.Entries {
+		contents[ix] = keychainContentsEntry{
+			id:               e.ID,
+			entryType:        e.Type,
+			title:            e.Title,
+			site:             e.Site,
+			date:             e.Date,
+			folderID:         e.FolderID,
+			passwordStrength: e.PasswordStrength,
+			trashed:          e.Trashed,
+		}
+
+		err = validateItemID(e.ID)
+		if err != nil {
+			return fmt.Errorf("Invalid entry in index: %v", err)
+		}
+	}
+
+	k.contents = contents
+	return nil
+}
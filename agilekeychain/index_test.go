@@ -0,0 +1,76 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDumpIndex_LoadIndex(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	var index bytes.Buffer
+	err = keychain.DumpIndex(&index)
+	if err != nil {
+		t.Fatalf("DumpIndex() error = %v", err)
+	}
+	saved := index.String()
+
+	restored, err := NewAgileKeychain(keychainPath, "1Password", WithIndex(strings.NewReader(saved)))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from index: %v", err)
+	}
+
+	if len(restored.contents) != len(keychain.contents) {
+		t.Fatalf("Restored %d entries, want %d", len(restored.contents), len(keychain.contents))
+	}
+	for ix := range keychain.contents {
+		got, want := restored.contents[ix], keychain.contents[ix]
+		if got.id != want.id || got.title != want.title || !got.date.Equal(want.date) ||
+			got.passwordStrength != want.passwordStrength {
+			t.Errorf("Restored entry %d = %+v, want %+v", ix, got, want)
+		}
+	}
+
+	// prove the index is what's being used by doctoring a title in it
+	doctored := strings.Replace(saved, `"Title":"Hulu"`, `"Title":"Hulu (cached)"`, 1)
+	err = restored.LoadIndex(strings.NewReader(doctored))
+	if err != nil {
+		t.Fatalf("LoadIndex() error = %v", err)
+	}
+	item, err := restored.GetItem("13C8E12AC8E54B1F873BAB0824E521BC")
+	if err != nil || item.Title != "Hulu (cached)" {
+		t.Errorf("GetItem() after LoadIndex = %+v, %v, want cached title", item, err)
+	}
+
+	// once contents.js changes, the index is stale and contents.js is reparsed
+	contentsPath := path.Join(keychainPath, "data", "default", "contents.js")
+	later := time.Now().Add(time.Hour)
+	err = os.Chtimes(contentsPath, later, later)
+	if err != nil {
+		t.Fatalf("Failed to touch contents.js: %v", err)
+	}
+
+	err = restored.LoadIndex(strings.NewReader(doctored))
+	if err != nil {
+		t.Fatalf("LoadIndex() of stale index error = %v", err)
+	}
+	item, err = restored.GetItem("13C8E12AC8E54B1F873BAB0824E521BC")
+	if err != nil || item.Title != "Hulu" {
+		t.Errorf("GetItem() after stale LoadIndex = %+v, %v, want title from contents.js", item, err)
+	}
+
+	// garbage falls back to a full parse too
+	err = restored.LoadIndex(strings.NewReader("not an index"))
+	if err != nil || restored.Length() != 19 {
+		t.Errorf("LoadIndex() of garbage = %v with %d entries", err, restored.Length())
+	}
+}
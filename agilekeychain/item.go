@@ -0,0 +1,501 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// item type of deleted items in contents.js
+const tombstoneType = "system.Tombstone"
+
+// Item is the unencrypted metadata of an item, as listed in contents.js
+type Item struct {
+	ID    string
+	Type  string
+	Title string
+	Site  string
+	Date  time.Time
+	// PasswordStrength is 1Password's 0-100 rating of the item's password
+	// when it was last saved, or 0 if it wasn't rated
+	PasswordStrength int
+	// FolderID is the id of the folder the item is in, or empty
+	FolderID string
+	// Trashed is set for items in the trash, and for deleted items
+	Trashed bool
+}
+
+func (e keychainContentsEntry) item() Item {
+	return Item{
+		ID:               e.id,
+		Type:             e.entryType,
+		Title:            e.title,
+		Site:             e.site,
+		Date:             e.date,
+		PasswordStrength: e.passwordStrength,
+		FolderID:         e.folderID,
+		Trashed:          e.trashed,
+	}
+}
+
+// List returns the metadata of every item in the keychain, in contents.js
+// order.  Deleted items are included, with Type "system.Tombstone".
+func (k *AgileKeychain) List() []Item {
+	ret := make([]Item, len(k.contents))
+	for ix, entry := range k.contents {
+		ret[ix] = entry.item()
+	}
+	return ret
+}
+
+// GetItem returns the metadata of the item with the given id
+func (k *AgileKeychain) GetItem(id string) (*Item, error) {
+	for _, entry := range k.contents {
+		if entry.id == id {
+			item := entry.item()
+			return &item, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrItemNotFound, id)
+}
+
+// GetByID returns the metadata of the item with the given id, as GetItem
+// does, after loading the item's .1password file: a keychain whose
+// contents.js lists an item it doesn't have, or one whose file is for a
+// different item, is caught at lookup rather than at the first decrypt.  A
+// title or site that contents.js leaves empty is filled in from the file.
+// The error wraps ErrItemNotFound if no item has the given id.
+func (k *AgileKeychain) GetByID(id string) (*Item, error) {
+	item, err := k.GetItem(id)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := k.loadItemFile(id)
+	if err != nil {
+		return nil, err
+	}
+	if file.UUID != "" && file.UUID != id {
+		return nil, fmt.Errorf("Item file for %s is for item %s", id, file.UUID)
+	}
+
+	if item.Title == "" {
+		item.Title = file.Title
+	}
+	if item.Site == "" {
+		item.Site = file.Location
+	}
+	return item, nil
+}
+
+// GetItemByPrefix returns the metadata of the one item whose id starts with
+// prefix, ignoring case, like a git short hash.  If more than one item
+// matches, the error wraps ErrAmbiguousPrefix and lists the matching ids.
+func (k *AgileKeychain) GetItemByPrefix(prefix string) (*Item, error) {
+	var matches []keychainContentsEntry
+	for _, entry := range k.contents {
+		if len(entry.id) >= len(prefix) && strings.EqualFold(entry.id[:len(prefix)], prefix) {
+			matches = append(matches, entry)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: no id starts with %s", ErrItemNotFound, prefix)
+	case 1:
+		item := matches[0].item()
+		return &item, nil
+	default:
+		ids := make([]string, len(matches))
+		for ix, entry := range matches {
+			ids[ix] = entry.id
+		}
+		return nil, fmt.Errorf("%w %s matches %s", ErrAmbiguousPrefix, prefix, strings.Join(ids, ", "))
+	}
+}
+
+// ItemSecurityLevel returns the security level, "SL3" or "SL5", of the key
+// that the item with the given id is encrypted with.  This only reads the
+// item's unencrypted metadata: the key id if it has one, or else the level
+// declared in its openContents.
+func (k *AgileKeychain) ItemSecurityLevel(id string) (string, error) {
+	_, err := k.GetItem(id)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := k.loadItemFile(id)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := k.keyForItem(item)
+	if err != nil {
+		return "", err
+	}
+	return key.level.String(), nil
+}
+
+// KeyForItem returns the identifier of the key that the item with the given
+// id is encrypted with, as listed by KeyIdentifiers.  Like ItemSecurityLevel
+// this only reads the item's unencrypted metadata.  Fails if the item names
+// a key that isn't loaded.
+func (k *AgileKeychain) KeyForItem(id string) (string, error) {
+	_, err := k.GetItem(id)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := k.loadItemFile(id)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := k.keyForItem(item)
+	if err != nil {
+		return "", err
+	}
+	return key.id, nil
+}
+
+// DecryptEntry decrypts the item described by entry, as returned by
+// GetItem or ParseContents, and writes its decrypted JSON to w.  Unlike
+// DecryptItem, nothing is parsed or kept once it's written, so a full export
+// can stream every item's JSON without holding more than one in memory.
+func (k *AgileKeychain) DecryptEntry(entry Item, w io.Writer) error {
+	return k.withDecryptedItem(entry.ID, func(item *itemFile, plaintext []byte) error {
+		_, err := w.Write(plaintext)
+		return err
+	})
+}
+
+// GetItemNotes decrypts the item with the given id and returns its free-form
+// notes, which any type of item can have.  Returns an empty string if the item
+// has no notes.
+func (k *AgileKeychain) GetItemNotes(id string) (string, error) {
+	data, err := k.DecryptItem(id)
+	if err != nil {
+		return "", err
+	}
+
+	notes, _ := data["notesPlain"].(string)
+	return notes, nil
+}
+
+// PasswordStrength decrypts the item with the given id and returns the
+// strength score stored in it as "passwordStrength", which 1Password records
+// alongside passwords it generated.  Unlike Item.PasswordStrength, taken from
+// contents.js, it's only there for generated passwords.  Returns an error
+// wrapping ErrNoPasswordStrength if the item has none.
+func (k *AgileKeychain) PasswordStrength(id string) (int, error) {
+	data, err := k.DecryptItem(id)
+	if err != nil {
+		return 0, err
+	}
+
+	strength, ok := data["passwordStrength"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("%w: item %s", ErrNoPasswordStrength, id)
+	}
+	return int(strength), nil
+}
+
+// OrphanedItemFiles returns the paths of .1password files in the keychain
+// that have no corresponding entry in contents.js
+func (k *AgileKeychain) OrphanedItemFiles() ([]string, error) {
+	if k.itemOpener != nil {
+		return nil, errNoDirectory("list item files")
+	}
+
+	dataDir := k.dataDir()
+	files, err := fs.ReadDir(k.dirFS(), k.vaultDir())
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(k.contents))
+	for _, entry := range k.contents {
+		known[entry.id] = true
+	}
+
+	orphans := []string{}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || path.Ext(name) != ".1password" {
+			continue
+		}
+
+		if !known[strings.TrimSuffix(name, ".1password")] {
+			orphans = append(orphans, path.Join(dataDir, name))
+		}
+	}
+
+	return orphans, nil
+}
+
+// RawItemFiles returns the bytes of the .1password file for every item in
+// contents.js, keyed by item id, exactly as they are on disk: no ItemStage is
+// run and nothing is parsed or decrypted.  Item ids are checked the same way
+// as everywhere else, so a hostile contents.js can't read files outside the
+// vault.
+func (k *AgileKeychain) RawItemFiles() (map[string][]byte, error) {
+	ret := make(map[string][]byte, len(k.contents))
+
+	for _, entry := range k.contents {
+		data, err := k.openItemFile(entry.id)
+		if err != nil {
+			return nil, err
+		}
+		ret[entry.id] = data
+	}
+
+	return ret, nil
+}
+
+// count the fields in a decrypted item.  Logins keep their form fields in a
+// "fields" array and newer items group fields into "sections"; for everything
+// else each top-level key is a field.
+func countFields(data map[string]interface{}) int {
+	fields, hasFields := data["fields"].([]interface{})
+	sections, hasSections := data["sections"].([]interface{})
+	if !hasFields && !hasSections {
+		return len(data)
+	}
+
+	count := len(fields)
+	for _, rawSection := range sections {
+		section, ok := rawSection.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		sectionFields, _ := section["fields"].([]interface{})
+		count += len(sectionFields)
+	}
+	return count
+}
+
+// FieldCounts maps the id of each item to the number of fields it contains.
+// This decrypts every item in the keychain.  Items that fail to decrypt are
+// left out of the map and reported together in an ItemErrors.
+func (k *AgileKeychain) FieldCounts() (map[string]int, error) {
+	counts := make(map[string]int, len(k.contents))
+	failures := ItemErrors{}
+
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		data, err := k.DecryptItem(entry.id)
+		if err != nil {
+			failures[entry.id] = err
+			continue
+		}
+
+		counts[entry.id] = countFields(data)
+	}
+
+	if len(failures) > 0 {
+		return counts, failures
+	}
+	return counts, nil
+}
+
+// DecryptedItem is the outcome of decrypting one item with DecryptedIter
+type DecryptedItem struct {
+	Data map[string]interface{}
+	Err  error
+}
+
+// DecryptedIter returns an iterator over the decrypted items in the keychain,
+// for use with range:
+//
+//	for id, item := range keychain.DecryptedIter(passphrase) { ... }
+//
+// Items are decrypted one at a time as the loop advances, so breaking out
+// early skips the remaining work.  A failure to decrypt an item is reported
+// in its DecryptedItem rather than ending the iteration; a failure to unlock
+// the keychain is yielded once with an empty id.
+func (k *AgileKeychain) DecryptedIter(passphrase string) iter.Seq2[string, DecryptedItem] {
+	return func(yield func(string, DecryptedItem) bool) {
+		err := k.loadEncryptionKeys(passphrase)
+		if err != nil {
+			yield("", DecryptedItem{Err: err})
+			return
+		}
+
+		for _, entry := range k.contents {
+			if entry.entryType == tombstoneType {
+				continue
+			}
+
+			data, err := k.DecryptItem(entry.id)
+			if !yield(entry.id, DecryptedItem{Data: data, Err: err}) {
+				return
+			}
+		}
+	}
+}
+
+// DecryptStats decrypts every item in the keychain with passphrase and counts
+// how many of the total decrypt to valid JSON, for health monitoring where
+// the individual failures, as the ItemErrors from FieldCounts or
+// SecurityAudit would give, aren't wanted.  Deleted items aren't counted.
+// err is only set if passphrase doesn't unlock the keychain.  The keys
+// already loaded are used if passphrase is the one that unlocked them, so
+// calling this repeatedly doesn't derive them every time.
+func (k *AgileKeychain) DecryptStats(passphrase string) (total int, succeeded int, err error) {
+	err = k.unlockWith(passphrase)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		total++
+		itemErr := k.withDecryptedItem(entry.id, func(item *itemFile, plaintext []byte) error {
+			if !json.Valid(plaintext) {
+				return errors.New("invalid JSON")
+			}
+			return nil
+		})
+		if itemErr == nil {
+			succeeded++
+		}
+	}
+
+	return total, succeeded, nil
+}
+
+// VerifyDecryptedJSON decrypts every item in the keychain with passphrase and
+// returns the ids, in contents.js order, of those that decrypt to something
+// other than valid JSON.  A payload decrypted with the wrong key or from a
+// corrupted file usually fails its padding check, but about one time in 256
+// it passes and yields garbage, which this catches.  Items that don't decrypt
+// at all aren't in the list; they're reported together in an ItemErrors.
+// Deleted items aren't checked.  As with DecryptStats, the keys are only
+// derived from passphrase if the keychain is locked or was unlocked with a
+// different one.
+func (k *AgileKeychain) VerifyDecryptedJSON(passphrase string) ([]string, error) {
+	err := k.unlockWith(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := []string{}
+	failures := ItemErrors{}
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		valid := true
+		err := k.withDecryptedItem(entry.id, func(item *itemFile, plaintext []byte) error {
+			valid = json.Valid(plaintext)
+			return nil
+		})
+		if err != nil {
+			failures[entry.id] = err
+		} else if !valid {
+			invalid = append(invalid, entry.id)
+		}
+	}
+
+	if len(failures) > 0 {
+		return invalid, failures
+	}
+	return invalid, nil
+}
+
+// ItemsByMonth groups the items by the year and month, in local time and
+// formatted "2006-01", in which they were created, each group in contents.js
+// order.  The creation date comes from the item's .1password file; an item
+// whose file can't be read or has no createdAt is counted by its contents.js
+// date, which is when it was last saved.  Deleted items aren't included.
+func (k *AgileKeychain) ItemsByMonth() map[string][]Item {
+	ret := make(map[string][]Item)
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		created := entry.date
+		file, err := k.loadItemFile(entry.id)
+		if err == nil && file.CreatedAt != 0 {
+			created = parseDate(file.CreatedAt, k.dateUnit)
+		}
+
+		month := created.Format("2006-01")
+		ret[month] = append(ret[month], entry.item())
+	}
+	return ret
+}
+
+// RecentItems returns up to n items, most recently modified first.  Items
+// modified at the same time are ordered by id.
+func (k *AgileKeychain) RecentItems(n int) []Item {
+	items := make([]Item, 0, len(k.contents))
+	for _, entry := range k.contents {
+		if entry.entryType != tombstoneType {
+			items = append(items, entry.item())
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].Date.Equal(items[j].Date) {
+			return items[i].Date.After(items[j].Date)
+		}
+		return items[i].ID < items[j].ID
+	})
+
+	if n < 0 {
+		n = 0
+	}
+	if n < len(items) {
+		items = items[:n]
+	}
+	return items
+}
+
+// DuplicateItems groups items that have the same title and site, returning
+// only groups with more than one member.  Groups, and the items within them,
+// are in contents.js order.  Nothing is decrypted.
+func (k *AgileKeychain) DuplicateItems() [][]Item {
+	type titleSite struct {
+		title string
+		site  string
+	}
+
+	groups := make(map[titleSite][]Item)
+	var order []titleSite
+
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		key := titleSite{title: entry.title, site: entry.site}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], entry.item())
+	}
+
+	ret := [][]Item{}
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			ret = append(ret, groups[key])
+		}
+	}
+	return ret
+}
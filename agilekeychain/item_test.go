@@ -0,0 +1,746 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// copy the example1 fixture into a temporary directory so tests can modify it
+func copyFixture(t *testing.T) string {
+	t.Helper()
+
+	src := "../testdata/agilekeychain/example1/1Password.agilekeychain"
+	dest := path.Join(t.TempDir(), "1Password.agilekeychain")
+
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := path.Join(dest, strings.TrimPrefix(p, src))
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(target, data, 0644)
+	})
+	if err != nil {
+		t.Fatalf("Failed to copy fixture: %v", err)
+	}
+
+	return dest
+}
+
+func TestOrphanedItemFiles(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	orphans, err := keychain.OrphanedItemFiles()
+	if err != nil {
+		t.Fatalf("OrphanedItemFiles() error = %v", err)
+	}
+	if len(orphans) != 0 {
+		t.Errorf("Got orphans in pristine fixture: %v", orphans)
+	}
+
+	orphanPath := path.Join(keychainPath, "data", "default", "0123456789ABCDEF0123456789ABCDEF.1password")
+	err = ioutil.WriteFile(orphanPath, []byte("{}"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write orphan: %v", err)
+	}
+
+	orphans, err = keychain.OrphanedItemFiles()
+	if err != nil {
+		t.Fatalf("OrphanedItemFiles() error = %v", err)
+	}
+	if !reflect.DeepEqual(orphans, []string{orphanPath}) {
+		t.Errorf("OrphanedItemFiles() = %v, want %v", orphans, []string{orphanPath})
+	}
+}
+
+func TestItem_PasswordStrength(t *testing.T) {
+	fixturePath := "../testdata/agilekeychain/strength/1Password.agilekeychain"
+
+	keychain, err := NewAgileKeychain(fixturePath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	for id, want := range map[string]int{
+		"57E4A7E057E4A7E057E4A7E057E4A700": 0,
+		"57E4A7E057E4A7E057E4A7E057E4A742": 42,
+		"57E4A7E057E4A7E057E4A7E057E4A7FF": 100,
+	} {
+		item, err := keychain.GetItem(id)
+		if err != nil {
+			t.Fatalf("GetItem(%s) error = %v", id, err)
+		}
+		if item.PasswordStrength != want {
+			t.Errorf("GetItem(%s).PasswordStrength = %d, want %d", id, item.PasswordStrength, want)
+		}
+	}
+}
+
+func TestRawItemFiles(t *testing.T) {
+	fixturePath := "../testdata/agilekeychain/example1/1Password.agilekeychain"
+
+	keychain, err := NewAgileKeychain(fixturePath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	files, err := keychain.RawItemFiles()
+	if err != nil {
+		t.Fatalf("RawItemFiles() error = %v", err)
+	}
+	if len(files) != keychain.Length() {
+		t.Errorf("RawItemFiles() returned %d files, want %d", len(files), keychain.Length())
+	}
+
+	for id, data := range files {
+		want, err := ioutil.ReadFile(path.Join(fixturePath, "data", "default", id+".1password"))
+		if err != nil {
+			t.Fatalf("Failed to read item file: %v", err)
+		}
+		if !bytes.Equal(data, want) {
+			t.Errorf("RawItemFiles()[%s] doesn't match the file on disk", id)
+		}
+	}
+}
+
+func TestFieldCounts(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	counts, err := keychain.FieldCounts()
+	if err != nil {
+		t.Fatalf("FieldCounts() error = %v", err)
+	}
+
+	if len(counts) != 18 {
+		t.Errorf("Got counts for wrong number of items: %d", len(counts))
+	}
+	// Hulu login: username, password, a button and a checkbox
+	if counts["13C8E12AC8E54B1F873BAB0824E521BC"] != 4 {
+		t.Errorf("Got wrong count for Hulu: %d", counts["13C8E12AC8E54B1F873BAB0824E521BC"])
+	}
+	// FTP account: notesPlain, password, path, server, username
+	if counts["4E36C011EE8348B1B24418218B04018C"] != 5 {
+		t.Errorf("Got wrong count for FTP: %d", counts["4E36C011EE8348B1B24418218B04018C"])
+	}
+
+	// a missing item file shouldn't stop the others from being counted
+	err = os.Remove(path.Join(keychainPath, "data", "default", "13C8E12AC8E54B1F873BAB0824E521BC.1password"))
+	if err != nil {
+		t.Fatalf("Failed to remove item file: %v", err)
+	}
+
+	counts, err = keychain.FieldCounts()
+	itemErrs, ok := err.(ItemErrors)
+	if !ok || len(itemErrs) != 1 || itemErrs["13C8E12AC8E54B1F873BAB0824E521BC"] == nil {
+		t.Errorf("FieldCounts() error = %v, want ItemErrors for the Hulu item", err)
+	}
+	if len(counts) != 17 {
+		t.Errorf("Got counts for wrong number of items: %d", len(counts))
+	}
+}
+
+func TestDecryptedIter(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/example1/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	seen := 0
+	for id, item := range keychain.DecryptedIter("1Password") {
+		if item.Err != nil {
+			t.Errorf("Got error for item %s: %v", id, item.Err)
+		}
+		if item.Data == nil {
+			t.Errorf("Got nil data for item %s", id)
+		}
+		seen++
+	}
+	if seen != 18 {
+		t.Errorf("Iterated over wrong number of items: %d", seen)
+	}
+
+	seen = 0
+	for range keychain.DecryptedIter("1Password") {
+		seen++
+		if seen == 3 {
+			break
+		}
+	}
+	if seen != 3 {
+		t.Errorf("Early break iterated over wrong number of items: %d", seen)
+	}
+
+	for id, item := range keychain.DecryptedIter("wrong passphrase") {
+		if item.Err == nil || id != "" {
+			t.Errorf("Got id %q and error %v with wrong passphrase", id, item.Err)
+		}
+	}
+
+	// a failed unlock must not clobber the keys that were already loaded
+	_, err = keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C")
+	if err != nil {
+		t.Errorf("DecryptItem() after wrong passphrase error = %v", err)
+	}
+}
+
+func TestGetItemByPrefix(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/example1/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	item, err := keychain.GetItemByPrefix("4e36")
+	if err != nil {
+		t.Fatalf("GetItemByPrefix() error = %v", err)
+	}
+	if item.ID != "4E36C011EE8348B1B24418218B04018C" || item.Title != "Company's FTP" {
+		t.Errorf("GetItemByPrefix() = %+v", item)
+	}
+
+	// F3707FA5..., F5F099B2..., F7883ADD... and F78CEC04...
+	_, err = keychain.GetItemByPrefix("F")
+	if !errors.Is(err, ErrAmbiguousPrefix) {
+		t.Errorf("GetItemByPrefix() of ambiguous prefix error = %v, want ErrAmbiguousPrefix", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "F7883ADDE5944B349ABB5CBEC20F39BE") {
+		t.Errorf("Ambiguous prefix error doesn't list the matches: %v", err)
+	}
+
+	_, err = keychain.GetItemByPrefix("0000")
+	if !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("GetItemByPrefix() of unknown prefix error = %v, want ErrItemNotFound", err)
+	}
+}
+
+func TestRecentItems(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/example1/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	// ten items share the latest timestamp, so these come first by id
+	recent := keychain.RecentItems(3)
+	want := []string{"72366D161D9E43D98E58EB801DAD1EF8", "D06307ADA44C4031BA2FF4B174DE79CB", "D1820AA8CB534AC6A4B5A2C0263FD3B2"}
+	if len(recent) != len(want) {
+		t.Fatalf("RecentItems(3) returned %d items", len(recent))
+	}
+	for ix, id := range want {
+		if recent[ix].ID != id {
+			t.Errorf("RecentItems(3)[%d] = %s, want %s", ix, recent[ix].ID, id)
+		}
+	}
+
+	all := keychain.RecentItems(100)
+	if len(all) != 18 {
+		t.Errorf("RecentItems(100) returned %d items, want all 18", len(all))
+	}
+	for ix := 1; ix < len(all); ix++ {
+		if all[ix].Date.After(all[ix-1].Date) {
+			t.Errorf("RecentItems not sorted: %v after %v", all[ix].Date, all[ix-1].Date)
+		}
+	}
+
+	if got := keychain.RecentItems(0); len(got) != 0 {
+		t.Errorf("RecentItems(0) returned %d items", len(got))
+	}
+}
+
+func TestDecryptStats(t *testing.T) {
+	keychainPath := copyFixture(t)
+	logger := &recordingLogger{}
+	keychain, err := NewAgileKeychain(keychainPath, "1Password", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	logger.lines = nil
+	total, succeeded, err := keychain.DecryptStats("1Password")
+	if err != nil || total != 18 || succeeded != 18 {
+		t.Errorf("DecryptStats() = %d, %d, %v, want 18, 18", total, succeeded, err)
+	}
+	// the keys from NewAgileKeychain are used rather than derived again
+	if len(logger.lines) != 0 {
+		t.Errorf("DecryptStats() with the loaded passphrase logged %q, want the keys reused", logger.lines)
+	}
+
+	// an item file that's no longer there
+	if err := os.Remove(path.Join(keychainPath, "data", "default", "5ADFF73C09004C448D45565BC4750DE2.1password")); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+	total, succeeded, err = keychain.DecryptStats("1Password")
+	if err != nil || total != 18 || succeeded != 17 {
+		t.Errorf("DecryptStats() with a missing item = %d, %d, %v, want 18, 17", total, succeeded, err)
+	}
+
+	if _, _, err := keychain.DecryptStats("wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("DecryptStats() with the wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestVerifyDecryptedJSON(t *testing.T) {
+	keychainPath := copyFixture(t)
+	logger := &recordingLogger{}
+	keychain, err := NewAgileKeychain(keychainPath, "1Password", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	logger.lines = nil
+	invalid, err := keychain.VerifyDecryptedJSON("1Password")
+	if err != nil || len(invalid) != 0 {
+		t.Errorf("VerifyDecryptedJSON() = %v, %v, want nothing invalid", invalid, err)
+	}
+	if len(logger.lines) != 0 {
+		t.Errorf("VerifyDecryptedJSON() with the loaded passphrase logged %q, want the keys reused", logger.lines)
+	}
+
+	// a locked keychain has its keys derived again
+	keychain.Close()
+	invalid, err = keychain.VerifyDecryptedJSON("1Password")
+	if err != nil || len(invalid) != 0 {
+		t.Errorf("VerifyDecryptedJSON() after Close = %v, %v, want nothing invalid", invalid, err)
+	}
+
+	// Hulu decrypts, but to garbage, and Tumblr's file is gone
+	const huluID = "13C8E12AC8E54B1F873BAB0824E521BC"
+	const tumblrID = "5ADFF73C09004C448D45565BC4750DE2"
+	huluPath := path.Join(keychainPath, "data", "default", huluID+".1password")
+	data, err := ioutil.ReadFile(huluPath)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	raw["encrypted"], err = encryptItemPayload(keychain.encKeys.sl5.key, []byte("\x8f\x02garbage"))
+	if err != nil {
+		t.Fatalf("encryptItemPayload() error = %v", err)
+	}
+	data, err = json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+	if err := ioutil.WriteFile(huluPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.Remove(path.Join(keychainPath, "data", "default", tumblrID+".1password")); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+
+	invalid, err = keychain.VerifyDecryptedJSON("1Password")
+	if !reflect.DeepEqual(invalid, []string{huluID}) {
+		t.Errorf("VerifyDecryptedJSON() = %v, want [%s]", invalid, huluID)
+	}
+	var itemErrs ItemErrors
+	if !errors.As(err, &itemErrs) || len(itemErrs) != 1 || itemErrs[tumblrID] == nil {
+		t.Errorf("VerifyDecryptedJSON() error = %v, want an ItemErrors for %s", err, tumblrID)
+	}
+
+	if _, err := keychain.VerifyDecryptedJSON("wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("VerifyDecryptedJSON() with the wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestItemsByMonth(t *testing.T) {
+	keychainPath := copyFixture(t)
+	appendContentsEntries(t, keychainPath,
+		`["0123456789ABCDEF0123456789ABCDEF","webforms.WebForm","No file","",1405425600,"",0,"N"]`)
+
+	// the fixture was made in March 2013; say Hulu was created in January
+	// 2012 and only last saved then
+	huluPath := path.Join(keychainPath, "data", "default", "13C8E12AC8E54B1F873BAB0824E521BC.1password")
+	data, err := ioutil.ReadFile(huluPath)
+	if err != nil {
+		t.Fatalf("Failed to read item: %v", err)
+	}
+	data = bytes.Replace(data, []byte(`"createdAt":1362350139`), []byte(`"createdAt":1326628800`), 1)
+	err = ioutil.WriteFile(huluPath, data, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write item: %v", err)
+	}
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	byMonth := keychain.ItemsByMonth()
+	if len(byMonth) != 3 || len(byMonth["2013-03"]) != 17 || len(byMonth["2012-01"]) != 1 || len(byMonth["2014-07"]) != 1 {
+		t.Fatalf("ItemsByMonth() = %v, want 17 items in 2013-03 and 1 each in 2012-01 and 2014-07", byMonth)
+	}
+	if byMonth["2012-01"][0].ID != "13C8E12AC8E54B1F873BAB0824E521BC" {
+		t.Errorf("ItemsByMonth()[2012-01] = %v, want Hulu", byMonth["2012-01"])
+	}
+	// an item without a file is counted by its contents.js date
+	if byMonth["2014-07"][0].ID != "0123456789ABCDEF0123456789ABCDEF" {
+		t.Errorf("ItemsByMonth()[2014-07] = %v, want the item without a file", byMonth["2014-07"])
+	}
+
+	var empty AgileKeychain
+	if got := empty.ItemsByMonth(); got == nil || len(got) != 0 {
+		t.Errorf("ItemsByMonth() on an empty keychain = %#v, want empty map", got)
+	}
+}
+
+func TestItemSecurityLevel(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/example1/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		// The Unofficial Apple Weblog
+		{id: "D8F79F17D6384808848B213EB4946ECA", want: "SL3"},
+		// Tumblr
+		{id: "5ADFF73C09004C448D45565BC4750DE2", want: "SL5"},
+	}
+	for _, tt := range tests {
+		got, err := keychain.ItemSecurityLevel(tt.id)
+		if err != nil {
+			t.Errorf("ItemSecurityLevel(%s) error = %v", tt.id, err)
+		}
+		if got != tt.want {
+			t.Errorf("ItemSecurityLevel(%s) = %s, want %s", tt.id, got, tt.want)
+		}
+	}
+
+	_, err = keychain.ItemSecurityLevel("00000000000000000000000000000000")
+	if !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("ItemSecurityLevel() of unknown id error = %v, want ErrItemNotFound", err)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	keychainPath := copyFixture(t)
+	dataDir := path.Join(keychainPath, "data", "default")
+	appendContentsEntries(t, keychainPath,
+		`["0123456789ABCDEF0123456789ABCDEF","webforms.WebForm","No file","",1362350139,"",0,"N"]`,
+		`["1111111111111111111111111111111A","wallet.onlineservices.FTP",null,null,1362350139,"",0,"N"]`,
+		`["1111111111111111111111111111111B","wallet.onlineservices.FTP","Copied","",1362350139,"",0,"N"]`)
+
+	data, err := ioutil.ReadFile(path.Join(dataDir, "4E36C011EE8348B1B24418218B04018C.1password"))
+	if err != nil {
+		t.Fatalf("Failed to read item: %v", err)
+	}
+	// an item with no title in contents.js, and a file copied from another
+	// item without changing its uuid
+	renamed := strings.Replace(string(data), "4E36C011EE8348B1B24418218B04018C", "1111111111111111111111111111111A", 1)
+	err = ioutil.WriteFile(path.Join(dataDir, "1111111111111111111111111111111A.1password"), []byte(renamed), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write item: %v", err)
+	}
+	err = ioutil.WriteFile(path.Join(dataDir, "1111111111111111111111111111111B.1password"), data, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write item: %v", err)
+	}
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	item, err := keychain.GetByID("4E36C011EE8348B1B24418218B04018C")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if item.ID != "4E36C011EE8348B1B24418218B04018C" || item.Type != "wallet.onlineservices.FTP" {
+		t.Errorf("GetByID() = %+v", item)
+	}
+
+	if _, err := keychain.GetByID("00000000000000000000000000000000"); !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("GetByID() of unknown id error = %v, want ErrItemNotFound", err)
+	}
+	if _, err := keychain.GetByID("0123456789ABCDEF0123456789ABCDEF"); err == nil || errors.Is(err, ErrItemNotFound) {
+		t.Errorf("GetByID() of an item without a file error = %v, want a file error", err)
+	}
+
+	item, err = keychain.GetByID("1111111111111111111111111111111A")
+	if err != nil {
+		t.Fatalf("GetByID() of an item without a title error = %v", err)
+	}
+	if item.Title != "Company's FTP" {
+		t.Errorf("GetByID() title = %q, want it filled in from the file", item.Title)
+	}
+
+	if _, err := keychain.GetByID("1111111111111111111111111111111B"); err == nil {
+		t.Errorf("GetByID() of an item whose file is for another item succeeded")
+	}
+}
+
+func TestList(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	items := keychain.List()
+	if len(items) != keychain.Length() {
+		t.Fatalf("List() returned %d items, want %d", len(items), keychain.Length())
+	}
+
+	for _, item := range items {
+		got, err := keychain.GetItem(item.ID)
+		if err != nil || !reflect.DeepEqual(*got, item) {
+			t.Errorf("List() item %+v doesn't match GetItem() = %+v, %v", item, got, err)
+		}
+	}
+
+	want := Item{ID: "4E36C011EE8348B1B24418218B04018C", Type: "wallet.onlineservices.FTP"}
+	found := false
+	for _, item := range items {
+		if item.ID == want.ID {
+			found = item.Type == want.Type
+		}
+	}
+	if !found {
+		t.Errorf("List() doesn't include %+v", want)
+	}
+
+	// the fixture's only trashed entry is its deleted item
+	for _, item := range items {
+		if item.Trashed != (item.ID == "3A47A0E3FEE948ADA9028FF0DA053CDB") {
+			t.Errorf("List() item %s has Trashed = %v", item.ID, item.Trashed)
+		}
+	}
+
+	var empty AgileKeychain
+	if got := empty.List(); got == nil || len(got) != 0 {
+		t.Errorf("List() on an empty keychain = %#v, want empty slice", got)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestDecryptEntry(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	for _, entry := range keychain.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := keychain.DecryptEntry(entry.item(), &buf); err != nil {
+			t.Errorf("DecryptEntry(%s) error = %v", entry.id, err)
+			continue
+		}
+
+		var got map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Errorf("DecryptEntry(%s) wrote invalid JSON: %v", entry.id, err)
+			continue
+		}
+		want, err := keychain.DecryptItem(entry.id)
+		if err != nil {
+			t.Fatalf("DecryptItem() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DecryptEntry(%s) = %v, want %v", entry.id, got, want)
+		}
+	}
+
+	item, err := keychain.GetItem("4E36C011EE8348B1B24418218B04018C")
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if err := keychain.DecryptEntry(*item, failingWriter{}); err == nil {
+		t.Errorf("DecryptEntry() to a failing writer succeeded")
+	}
+	if err := keychain.DecryptEntry(Item{ID: "../encryptionKeys"}, ioutil.Discard); !errors.Is(err, ErrInvalidItemID) {
+		t.Errorf("DecryptEntry() of a bad id error = %v, want ErrInvalidItemID", err)
+	}
+}
+
+func TestKeyForItem(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	wantIDs := []string{"C6EA4955FD224185BD2A4579C89CA9D3", "91F7E2D5E3E54447819ABDD84CFB27A2"}
+	if got := keychain.KeyIdentifiers(); !reflect.DeepEqual(got, wantIDs) {
+		t.Errorf("KeyIdentifiers() = %v, want %v", got, wantIDs)
+	}
+
+	// The Unofficial Apple Weblog is SL3, Tumblr SL5
+	for id, want := range map[string]string{
+		"D8F79F17D6384808848B213EB4946ECA": wantIDs[0],
+		"5ADFF73C09004C448D45565BC4750DE2": wantIDs[1],
+	} {
+		got, err := keychain.KeyForItem(id)
+		if err != nil || got != want {
+			t.Errorf("KeyForItem(%s) = %s, %v, want %s", id, got, err, want)
+		}
+	}
+
+	keychain.Close()
+	if got := keychain.KeyIdentifiers(); got == nil || len(got) != 0 {
+		t.Errorf("KeyIdentifiers() after Close = %#v, want empty slice", got)
+	}
+}
+
+func TestGetItemNotes(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		want string
+	}{
+		{name: "Non-note item with notes", id: "4E36C011EE8348B1B24418218B04018C", want: "Sample FTP account."},
+		{name: "Login with empty notes", id: "358B7411EB8B45CD9CE592ED16F3E9DE", want: ""},
+		{name: "Login without notes", id: "13C8E12AC8E54B1F873BAB0824E521BC", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := keychain.GetItemNotes(tt.id)
+			if err != nil {
+				t.Fatalf("GetItemNotes() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetItemNotes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// add raw entries to the end of contents.js in a copy of a fixture
+func appendContentsEntries(t *testing.T, keychainPath string, entries ...string) {
+	t.Helper()
+
+	contentsPath := path.Join(keychainPath, "data", "default", "contents.js")
+	contents, err := ioutil.ReadFile(contentsPath)
+	if err != nil {
+		t.Fatalf("Failed to read contents: %v", err)
+	}
+
+	contents = bytes.TrimSuffix(bytes.TrimSpace(contents), []byte("]"))
+	for _, entry := range entries {
+		contents = append(append(contents, ','), entry...)
+	}
+	contents = append(contents, ']')
+
+	err = ioutil.WriteFile(contentsPath, contents, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write contents: %v", err)
+	}
+}
+
+func TestDuplicateItems(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	if dups := keychain.DuplicateItems(); len(dups) != 0 {
+		t.Errorf("Got duplicates in fixture without any: %v", dups)
+	}
+
+	appendContentsEntries(t, keychainPath,
+		`["0123456789ABCDEF0123456789ABCDE1","webforms.WebForm","Hulu","hulu.com",1362350141,"",0,"N"]`,
+		`["0123456789ABCDEF0123456789ABCDE2","webforms.WebForm","Hulu","hulu.com",1362350142,"",0,"N"]`,
+		// same title, different site isn't a duplicate
+		`["0123456789ABCDEF0123456789ABCDE3","webforms.WebForm","Skype","skype.example.com",1362350142,"",0,"N"]`)
+
+	keychain, err = NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	dups := keychain.DuplicateItems()
+	if len(dups) != 1 || len(dups[0]) != 3 {
+		t.Fatalf("DuplicateItems() = %v, want one group of three", dups)
+	}
+
+	want := []string{"13C8E12AC8E54B1F873BAB0824E521BC", "0123456789ABCDEF0123456789ABCDE1", "0123456789ABCDEF0123456789ABCDE2"}
+	for ix, id := range want {
+		if dups[0][ix].ID != id {
+			t.Errorf("DuplicateItems()[0][%d] = %s, want %s", ix, dups[0][ix].ID, id)
+		}
+	}
+}
+
+func TestPasswordStrength(t *testing.T) {
+	keychainPath := copyFixture(t)
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	const generatedID = "9E4E4A7ED9E4E4A7ED9E4E4A7ED9E401"
+	encrypted, err := encryptItemPayload(keychain.encKeys.sl5.key, []byte(`{"password":"Xk2#pq9!","passwordStrength":73}`))
+	if err != nil {
+		t.Fatalf("encryptItemPayload() error = %v", err)
+	}
+	itemJSON, err := json.Marshal(map[string]interface{}{
+		"uuid":         generatedID,
+		"title":        "Generated",
+		"typeName":     "passwords.Password",
+		"keyID":        keychain.encKeys.sl5.id,
+		"encrypted":    encrypted,
+		"openContents": map[string]interface{}{"securityLevel": "SL5"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+	err = ioutil.WriteFile(path.Join(keychainPath, "data", "default", generatedID+".1password"), itemJSON, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	appendContentsEntries(t, keychainPath, `["`+generatedID+`","passwords.Password","Generated","",1362350139,"",0,"N"]`)
+	if err := keychain.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	strength, err := keychain.PasswordStrength(generatedID)
+	if err != nil || strength != 73 {
+		t.Errorf("PasswordStrength() = %d, %v, want 73", strength, err)
+	}
+
+	_, err = keychain.PasswordStrength("13C8E12AC8E54B1F873BAB0824E521BC")
+	if !errors.Is(err, ErrNoPasswordStrength) {
+		t.Errorf("PasswordStrength() of an item without one error = %v, want ErrNoPasswordStrength", err)
+	}
+}
@@ -0,0 +1,90 @@
+package agilekeychain
+
+// ItemType is the kind of an item, decoded from its type name in contents.js
+type ItemType int
+
+const (
+	// ItemTypeUnknown is any type name not listed below
+	ItemTypeUnknown ItemType = iota
+	ItemTypeLogin
+	ItemTypePassword
+	ItemTypeSecureNote
+	ItemTypeIdentity
+	ItemTypeCreditCard
+	ItemTypeBankAccount
+	ItemTypeSoftwareLicense
+	ItemTypeDatabase
+	ItemTypeServer
+	ItemTypeWirelessRouter
+	ItemTypeEmailAccount
+	ItemTypeFTPAccount
+	ItemTypeMobileMe
+	ItemTypeInstantMessenger
+	ItemTypeISP
+	ItemTypeITunes
+	ItemTypeAmazonS3
+	ItemTypeGenericAccount
+	ItemTypeDriversLicense
+	ItemTypePassport
+	ItemTypeSocialSecurityNumber
+	ItemTypeHuntingLicense
+	ItemTypeMembership
+	ItemTypeRewardProgram
+	ItemTypeFolder
+	ItemTypeSavedSearch
+	ItemTypeTombstone
+)
+
+// the type names 1Password uses in contents.js and item files
+var itemTypeNames = map[string]ItemType{
+	webFormType:                              ItemTypeLogin,
+	"passwords.Password":                     ItemTypePassword,
+	"securenotes.SecureNote":                 ItemTypeSecureNote,
+	"identities.Identity":                    ItemTypeIdentity,
+	"wallet.financial.CreditCard":            ItemTypeCreditCard,
+	"wallet.financial.BankAccountUS":         ItemTypeBankAccount,
+	"wallet.computer.License":                ItemTypeSoftwareLicense,
+	"wallet.computer.Database":               ItemTypeDatabase,
+	"wallet.computer.UnixServer":             ItemTypeServer,
+	"wallet.computer.Router":                 ItemTypeWirelessRouter,
+	"wallet.onlineservices.Email.v2":         ItemTypeEmailAccount,
+	"wallet.onlineservices.FTP":              ItemTypeFTPAccount,
+	"wallet.onlineservices.DotMac":           ItemTypeMobileMe,
+	"wallet.onlineservices.InstantMessenger": ItemTypeInstantMessenger,
+	"wallet.onlineservices.ISP":              ItemTypeISP,
+	"wallet.onlineservices.iTunes":           ItemTypeITunes,
+	"wallet.onlineservices.AmazonS3":         ItemTypeAmazonS3,
+	"wallet.onlineservices.GenericAccount":   ItemTypeGenericAccount,
+	"wallet.government.DriversLicense":       ItemTypeDriversLicense,
+	"wallet.government.Passport":             ItemTypePassport,
+	"wallet.government.SsnUS":                ItemTypeSocialSecurityNumber,
+	"wallet.government.HuntingLicense":       ItemTypeHuntingLicense,
+	"wallet.membership.Membership":           ItemTypeMembership,
+	"wallet.membership.RewardProgram":        ItemTypeRewardProgram,
+	"system.folder.Regular":                  ItemTypeFolder,
+	"system.folder.SavedSearch":              ItemTypeSavedSearch,
+	tombstoneType:                            ItemTypeTombstone,
+}
+
+// ParseItemType decodes a contents.js type name, returning ItemTypeUnknown
+// for names it doesn't recognize
+func ParseItemType(typeName string) ItemType {
+	return itemTypeNames[typeName]
+}
+
+// ItemType decodes the item's Type
+func (i Item) ItemType() ItemType {
+	return ParseItemType(i.Type)
+}
+
+// UnknownItemTypes counts the items whose type name ParseItemType doesn't
+// recognize, keyed by the raw type name.  Only contents.js is consulted.
+func (k *AgileKeychain) UnknownItemTypes() map[string]int {
+	counts := make(map[string]int)
+	for _, entry := range k.contents {
+		if ParseItemType(entry.entryType) == ItemTypeUnknown {
+			counts[entry.entryType]++
+		}
+	}
+	return counts
+}
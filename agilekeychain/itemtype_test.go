@@ -0,0 +1,44 @@
+package agilekeychain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseItemType(t *testing.T) {
+	tests := map[string]ItemType{
+		"webforms.WebForm":            ItemTypeLogin,
+		"wallet.financial.CreditCard": ItemTypeCreditCard,
+		"system.Tombstone":            ItemTypeTombstone,
+		"wallet.custom.Spaceship":     ItemTypeUnknown,
+		"":                            ItemTypeUnknown,
+	}
+	for typeName, want := range tests {
+		if got := ParseItemType(typeName); got != want {
+			t.Errorf("ParseItemType(%q) = %v, want %v", typeName, got, want)
+		}
+	}
+}
+
+func TestUnknownItemTypes(t *testing.T) {
+	keychainPath := copyFixture(t)
+	appendContentsEntries(t, keychainPath,
+		`["0123456789ABCDEF0123456789ABCDE1","wallet.custom.Spaceship","Millennium Falcon","",1362350139,"",0,"N"]`,
+		`["0123456789ABCDEF0123456789ABCDE2","wallet.custom.Spaceship","X-Wing","",1362350139,"",0,"N"]`,
+		`["0123456789ABCDEF0123456789ABCDE3","com.example.Widget","Widget","",1362350139,"",0,"N"]`)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	want := map[string]int{"wallet.custom.Spaceship": 2, "com.example.Widget": 1}
+	if got := keychain.UnknownItemTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("UnknownItemTypes() = %v, want %v", got, want)
+	}
+
+	item, err := keychain.GetItem("0123456789ABCDEF0123456789ABCDE3")
+	if err != nil || item.ItemType() != ItemTypeUnknown {
+		t.Errorf("GetItem().ItemType() = %v, %v, want ItemTypeUnknown", item, err)
+	}
+}
@@ -0,0 +1,59 @@
+package agilekeychain
+
+import (
+	"crypto/sha1"
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// shortest benchmark run RecommendIterations scales from; anything shorter
+// is dominated by timer resolution and scheduling noise
+const minBenchmarkDuration = 25 * time.Millisecond
+
+// RecommendIterations suggests a PBKDF2 iteration count for new or re-keyed
+// keychains such that deriving a key takes about targetDuration on this
+// machine.
+//
+// It times the same derivation the keychain uses, PBKDF2-SHA1 producing 32
+// bytes, starting at 1000 iterations and doubling until a run takes at least
+// 25ms (or targetDuration, if that's shorter), then scales the count linearly
+// to the target, since PBKDF2's cost is linear in its iteration count.  The
+// result is rounded to the nearest thousand and is never less than the
+// minimum SecurityAudit recommends.  Being a single timing on a possibly busy
+// machine it's only a rough guide; other machines that open the keychain may
+// be much slower.
+func RecommendIterations(targetDuration time.Duration) (int, error) {
+	if targetDuration <= 0 {
+		return 0, errors.New("Target duration must be positive")
+	}
+
+	benchmarkDuration := minBenchmarkDuration
+	if targetDuration < benchmarkDuration {
+		benchmarkDuration = targetDuration
+	}
+
+	password := []byte("RecommendIterations")
+	salt := make([]byte, 8)
+
+	iterations := 1000
+	var elapsed time.Duration
+	for {
+		start := time.Now()
+		pbkdf2.Key(password, salt, iterations, 32, sha1.New)
+		elapsed = time.Since(start)
+
+		if elapsed >= benchmarkDuration {
+			break
+		}
+		iterations *= 2
+	}
+
+	recommended := int(float64(iterations) * float64(targetDuration) / float64(elapsed))
+	recommended = (recommended + 500) / 1000 * 1000
+	if recommended < minRecommendedIterations {
+		recommended = minRecommendedIterations
+	}
+	return recommended, nil
+}
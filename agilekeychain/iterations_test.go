@@ -0,0 +1,29 @@
+package agilekeychain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecommendIterations(t *testing.T) {
+	_, err := RecommendIterations(0)
+	if err == nil {
+		t.Errorf("RecommendIterations(0) succeeded")
+	}
+
+	short, err := RecommendIterations(time.Microsecond)
+	if err != nil {
+		t.Fatalf("RecommendIterations() error = %v", err)
+	}
+	if short != minRecommendedIterations {
+		t.Errorf("RecommendIterations(1µs) = %d, want the floor %d", short, minRecommendedIterations)
+	}
+
+	long, err := RecommendIterations(time.Minute)
+	if err != nil {
+		t.Fatalf("RecommendIterations() error = %v", err)
+	}
+	if long <= minRecommendedIterations || long%1000 != 0 {
+		t.Errorf("RecommendIterations(1m) = %d, want a multiple of 1000 above the floor", long)
+	}
+}
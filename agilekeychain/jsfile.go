@@ -0,0 +1,71 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// the keychain's .js files are meant to be plain JSON, but some exporters
+// write them as actual javascript
+var jsAssignmentPrefix = regexp.MustCompile(`^(?:var|let|const)\s+[A-Za-z_$][A-Za-z0-9_$]*\s*=`)
+
+// relaxJSON turns a javascript file holding a single JSON value into plain
+// JSON by removing a leading byte order mark, // and /* */ comments, and a
+// variable assignment wrapper such as "var contents = ...;".  Comment
+// markers inside strings are left alone.
+func relaxJSON(data []byte) []byte {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	data = stripJSComments(data)
+	data = bytes.TrimSpace(data)
+
+	if loc := jsAssignmentPrefix.FindIndex(data); loc != nil {
+		data = bytes.TrimSpace(data[loc[1]:])
+	}
+	data = bytes.TrimSuffix(data, []byte(";"))
+
+	return bytes.TrimSpace(data)
+}
+
+// remove javascript comments, taking care not to treat // or /* inside a
+// string as the start of a comment
+func stripJSComments(data []byte) []byte {
+	ret := make([]byte, 0, len(data))
+	inString := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if inString {
+			ret = append(ret, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				ret = append(ret, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			ret = append(ret, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			end := bytes.IndexByte(data[i:], '\n')
+			if end < 0 {
+				return ret
+			}
+			i += end - 1
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return ret
+			}
+			i += end + 3
+		default:
+			ret = append(ret, c)
+		}
+	}
+
+	return ret
+}
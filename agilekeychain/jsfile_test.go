@@ -0,0 +1,109 @@
+package agilekeychain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRelaxJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "Plain JSON is unchanged",
+			input: `{"a":[1,2]}`,
+			want:  `{"a":[1,2]}`,
+		},
+		{
+			name:  "Byte order mark",
+			input: "\xef\xbb\xbf[1]",
+			want:  `[1]`,
+		},
+		{
+			name:  "Variable assignment wrapper",
+			input: "var contents = [1, 2];\n",
+			want:  `[1, 2]`,
+		},
+		{
+			name:  "Line and block comments",
+			input: "// header\n{/* inline */\"a\": 1 // trailing\n}",
+			want:  "{\"a\": 1 \n}",
+		},
+		{
+			name:  "Comment markers inside strings",
+			input: `{"url": "http://example.com/*x*/", "q": "a \" // b"}`,
+			want:  `{"url": "http://example.com/*x*/", "q": "a \" // b"}`,
+		},
+		{
+			name:  "Everything at once",
+			input: "\xef\xbb\xbf/* keys */\nlet encryptionKeys = {\"SL3\": \"x\"}; // done",
+			want:  `{"SL3": "x"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(relaxJSON([]byte(tt.input)))
+			if got != tt.want {
+				t.Errorf("relaxJSON() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAgileKeychain_JavascriptKeys(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/jskeys/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	if len(keychain.encKeys.keys) != 2 {
+		t.Errorf("Got wrong number of keys: %d", len(keychain.encKeys.keys))
+	}
+}
+
+func TestParseContents_JavascriptWrapper(t *testing.T) {
+	bare := `[["A","webforms.WebForm","One","",0,"",0,"N"]]`
+	want, err := ParseContents([]byte(bare))
+	if err != nil {
+		t.Fatalf("ParseContents(bare) error = %v", err)
+	}
+
+	for _, wrapped := range []string{
+		"var contents = " + bare + ";",
+		"// contents\nvar contents =\n" + bare + ";\n",
+		"\xef\xbb\xbf" + bare,
+	} {
+		got, err := ParseContents([]byte(wrapped))
+		if err != nil {
+			t.Errorf("ParseContents(%q) error = %v", wrapped, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseContents(%q) = %+v, want %+v", wrapped, got, want)
+		}
+	}
+
+	if _, err := ParseContents([]byte("var contents = [[;")); err == nil {
+		t.Error("ParseContents() of broken javascript error = nil, want error")
+	}
+}
+
+func TestNewAgileKeychain_JavascriptContents(t *testing.T) {
+	wrapped, err := NewAgileKeychain("../testdata/agilekeychain/jscontents/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	bare, err := NewAgileKeychain("../testdata/agilekeychain/base64wrapped/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	if got, want := wrapped.List(), bare.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %+v, want %+v", got, want)
+	}
+	if _, err := wrapped.DecryptItem("5ADFF73C09004C448D45565BC4750DE2"); err != nil {
+		t.Errorf("DecryptItem() error = %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package agilekeychain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// KeyringProvider fetches secrets from an OS keyring.  This package ships no
+// implementation, so as not to depend on any particular keyring library; wrap
+// whichever one you use (e.g. github.com/zalando/go-keyring) in a
+// KeyringProvider and pass it to WithKeyringProvider.
+type KeyringProvider interface {
+	// Get returns the secret stored for service and account
+	Get(service, account string) (string, error)
+}
+
+// WithKeyringProvider sets the keyring used by PassphraseFromKeyring
+func WithKeyringProvider(provider KeyringProvider) Option {
+	return func(k *AgileKeychain) {
+		k.keyring = provider
+	}
+}
+
+// PassphraseFromKeyring makes NewAgileKeychain fetch the passphrase from the
+// keyring set with WithKeyringProvider, instead of taking it as an argument,
+// so that it needn't be kept in a config file.  NewAgileKeychain fails if
+// there is no provider, or if it returns an empty passphrase.
+func PassphraseFromKeyring(service, account string) Option {
+	return func(k *AgileKeychain) {
+		k.keyringService = service
+		k.keyringAccount = account
+		k.useKeyring = true
+	}
+}
+
+// fetch the passphrase for PassphraseFromKeyring
+func (k *AgileKeychain) keyringPassphrase() (string, error) {
+	if k.keyring == nil {
+		return "", errors.New("PassphraseFromKeyring requires WithKeyringProvider")
+	}
+
+	passphrase, err := k.keyring.Get(k.keyringService, k.keyringAccount)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read passphrase for %s/%s from keyring: %v", k.keyringService, k.keyringAccount, err)
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("%w for %s/%s", ErrNoKeyringPassphrase, k.keyringService, k.keyringAccount)
+	}
+
+	return passphrase, nil
+}
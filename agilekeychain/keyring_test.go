@@ -0,0 +1,48 @@
+package agilekeychain
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeKeyring map[string]string
+
+func (f fakeKeyring) Get(service, account string) (string, error) {
+	return f[service+"/"+account], nil
+}
+
+type failingKeyring struct{}
+
+func (failingKeyring) Get(service, account string) (string, error) {
+	return "", errors.New("keyring locked")
+}
+
+func TestPassphraseFromKeyring(t *testing.T) {
+	fixturePath := "../testdata/agilekeychain/example1/1Password.agilekeychain"
+	keyring := fakeKeyring{"passync/example1": "1Password", "passync/wrong": "not it"}
+
+	_, err := NewAgileKeychain(fixturePath, "", WithKeyringProvider(keyring), PassphraseFromKeyring("passync", "example1"))
+	if err != nil {
+		t.Errorf("NewAgileKeychain() with keyring passphrase error = %v", err)
+	}
+
+	_, err = NewAgileKeychain(fixturePath, "", WithKeyringProvider(keyring), PassphraseFromKeyring("passync", "wrong"))
+	if err == nil {
+		t.Errorf("NewAgileKeychain() with wrong keyring passphrase succeeded")
+	}
+
+	_, err = NewAgileKeychain(fixturePath, "", WithKeyringProvider(keyring), PassphraseFromKeyring("passync", "missing"))
+	if !errors.Is(err, ErrNoKeyringPassphrase) {
+		t.Errorf("NewAgileKeychain() with missing keyring passphrase error = %v, want ErrNoKeyringPassphrase", err)
+	}
+
+	_, err = NewAgileKeychain(fixturePath, "", WithKeyringProvider(failingKeyring{}), PassphraseFromKeyring("passync", "example1"))
+	if err == nil {
+		t.Errorf("NewAgileKeychain() with failing keyring succeeded")
+	}
+
+	_, err = NewAgileKeychain(fixturePath, "", PassphraseFromKeyring("passync", "example1"))
+	if err == nil {
+		t.Errorf("NewAgileKeychain() without a keyring provider succeeded")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package agilekeychain
+
+// no flock here, so there's no way to tell
+func fileLocked(filePath string) (bool, error) {
+	return false, nil
+}
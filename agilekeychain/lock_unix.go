@@ -0,0 +1,28 @@
+//go:build unix
+
+package agilekeychain
+
+import (
+	"os"
+	"syscall"
+)
+
+// try to take a shared advisory lock on filePath without blocking, reporting
+// whether someone else holds an exclusive one
+func fileLocked(filePath string) (bool, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_SH|syscall.LOCK_NB)
+	if err == syscall.EWOULDBLOCK {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return false, syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
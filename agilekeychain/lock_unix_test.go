@@ -0,0 +1,35 @@
+//go:build unix
+
+package agilekeychain
+
+import (
+	"os"
+	"path"
+	"syscall"
+	"testing"
+)
+
+func TestIsLocked_HeldExclusively(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	// flock locks belong to the open file description, so a separate open of
+	// the same file conflicts just as another process would
+	f, err := os.Open(path.Join(keychainPath, "data", "default", "contents.js"))
+	if err != nil {
+		t.Fatalf("Failed to open contents: %v", err)
+	}
+	defer f.Close()
+
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		t.Fatalf("Failed to lock contents: %v", err)
+	}
+
+	locked, err := IsLocked(keychainPath)
+	if err != nil {
+		t.Fatalf("IsLocked() error = %v", err)
+	}
+	if !locked {
+		t.Errorf("IsLocked() = false while contents.js is locked exclusively")
+	}
+}
@@ -0,0 +1,23 @@
+package agilekeychain
+
+// Logger receives the keychain's diagnostic messages, such as which keys were
+// found and validated.  *log.Logger satisfies it.  Messages never include key
+// material or decrypted data.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// WithLogger sends the keychain's diagnostic messages to logger.  Without this
+// option they are discarded.
+func WithLogger(logger Logger) Option {
+	return func(k *AgileKeychain) {
+		k.logger = logger
+	}
+}
+
+// log a diagnostic message, if a Logger is set
+func (k *AgileKeychain) logf(format string, args ...interface{}) {
+	if k.logger != nil {
+		k.logger.Printf(format, args...)
+	}
+}
@@ -0,0 +1,44 @@
+package agilekeychain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	keychain, err := NewAgileKeychain(example1Path, "1Password", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	want := []string{"Found 2 keys"}
+	for _, id := range keychain.KeyIdentifiers() {
+		want = append(want, "validated key "+id)
+	}
+	if len(logger.lines) != len(want) {
+		t.Fatalf("logged %q, want %q", logger.lines, want)
+	}
+	for ix, line := range logger.lines {
+		if !strings.HasPrefix(line, want[ix]) {
+			t.Errorf("line %d = %q, want prefix %q", ix, line, want[ix])
+		}
+	}
+
+	logger.lines = nil
+	if err := keychain.loadEncryptionKeys("wrong"); err == nil {
+		t.Fatal("loadEncryptionKeys(wrong) error = nil, want error")
+	}
+	if len(logger.lines) < 2 || !strings.Contains(logger.lines[1], "failed") {
+		t.Errorf("logged %q for a wrong passphrase, want a failure", logger.lines)
+	}
+}
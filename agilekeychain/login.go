@@ -0,0 +1,151 @@
+package agilekeychain
+
+import (
+	"fmt"
+)
+
+// item type of login items in contents.js
+const webFormType = "webforms.WebForm"
+
+// LoginField is one of the form fields saved with a login
+type LoginField struct {
+	Name string
+	// "username", "password", or empty for other fields
+	Designation string
+	// the HTML input type, abbreviated: "T" for text, "P" for password, "E"
+	// for email, "C" for checkbox, "B" for button and so on
+	Type  string
+	Value string
+}
+
+// pull the form fields out of a decrypted login item, in order
+func parseLoginFields(data map[string]interface{}) []LoginField {
+	rawFields, _ := data["fields"].([]interface{})
+
+	fields := make([]LoginField, 0, len(rawFields))
+	for _, rawField := range rawFields {
+		field, ok := rawField.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var f LoginField
+		f.Name, _ = field["name"].(string)
+		f.Designation, _ = field["designation"].(string)
+		f.Type, _ = field["type"].(string)
+		f.Value, _ = field["value"].(string)
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// pull the username and password out of a decrypted login item by looking at
+// the designations of its form fields
+func loginCredentials(data map[string]interface{}) (username string, password string) {
+	for _, field := range parseLoginFields(data) {
+		switch field.Designation {
+		case "username":
+			if username == "" {
+				username = field.Value
+			}
+		case "password":
+			if password == "" {
+				password = field.Value
+			}
+		}
+	}
+	return username, password
+}
+
+// GetLoginFields decrypts the login with the given id and returns its form
+// fields in order, with their designations intact so that the username and
+// password can be told apart from the rest.  Returns an error if the item
+// isn't a login.
+func (k *AgileKeychain) GetLoginFields(id string) ([]LoginField, error) {
+	item, err := k.GetItem(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Type != webFormType {
+		return nil, fmt.Errorf("Item %s is a %s, not a login", id, item.Type)
+	}
+
+	data, err := k.DecryptItem(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLoginFields(data), nil
+}
+
+// Login is the username, password and URLs of a decrypted login item
+type Login struct {
+	Username string
+	Password string
+	// the item's location, then any further URLs saved with it
+	URLs []string
+}
+
+// pull a Login out of a decrypted login item and the location from its item
+// file.  The username and password are the first fields designated as such;
+// failing that, for logins saved without designations, the first text or
+// email field and the first password field.
+func parseLogin(data map[string]interface{}, location string) *Login {
+	login := &Login{URLs: []string{}}
+	login.Username, login.Password = loginCredentials(data)
+
+	for _, field := range parseLoginFields(data) {
+		switch {
+		case login.Username == "" && field.Designation == "" && (field.Type == "T" || field.Type == "E"):
+			login.Username = field.Value
+		case login.Password == "" && field.Designation == "" && field.Type == "P":
+			login.Password = field.Value
+		}
+	}
+
+	seen := make(map[string]bool)
+	addURL := func(url string) {
+		if url != "" && !seen[url] {
+			seen[url] = true
+			login.URLs = append(login.URLs, url)
+		}
+	}
+
+	addURL(location)
+	urls, _ := data["URLs"].([]interface{})
+	for _, rawURL := range urls {
+		entry, ok := rawURL.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		url, _ := entry["url"].(string)
+		addURL(url)
+	}
+	return login
+}
+
+// GetLogin decrypts the login with the given id and returns its username,
+// password and URLs.  Returns an error if the item isn't a login.
+func (k *AgileKeychain) GetLogin(id string) (*Login, error) {
+	item, err := k.GetItem(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Type != webFormType {
+		return nil, fmt.Errorf("Item %s is a %s, not a login", id, item.Type)
+	}
+
+	file, err := k.loadItemFile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := k.DecryptItem(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLogin(data, file.Location), nil
+}
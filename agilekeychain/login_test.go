@@ -0,0 +1,113 @@
+package agilekeychain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetLoginFields(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	// Dropbox
+	fields, err := keychain.GetLoginFields("468B1E24F93B413DAD57ABE6F1C01DF6")
+	if err != nil {
+		t.Fatalf("GetLoginFields() error = %v", err)
+	}
+
+	want := []LoginField{
+		{Name: "email", Designation: "username", Type: "T", Value: "wendy@appleseed.com"},
+		{Name: "password", Designation: "password", Type: "P", Value: "vet4juf4nim1ow6ay2ph"},
+		{Name: "", Designation: "", Type: "B", Value: "Log in"},
+		{Name: "remember_me", Designation: "", Type: "C", Value: ""},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("GetLoginFields() = %+v, want %+v", fields, want)
+	}
+
+	// the FTP account isn't a login
+	_, err = keychain.GetLoginFields("4E36C011EE8348B1B24418218B04018C")
+	if err == nil {
+		t.Errorf("GetLoginFields() of non-login did not fail")
+	}
+}
+
+func TestGetLogin(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	// Tumblr
+	login, err := keychain.GetLogin("5ADFF73C09004C448D45565BC4750DE2")
+	if err != nil {
+		t.Fatalf("GetLogin() error = %v", err)
+	}
+	want := &Login{Username: "wendy@appleseed.com", Password: "vow6wem2wo", URLs: []string{"http://www.tumblr.com/login"}}
+	if !reflect.DeepEqual(login, want) {
+		t.Errorf("GetLogin() = %+v, want %+v", login, want)
+	}
+
+	if _, err := keychain.GetLogin("4E36C011EE8348B1B24418218B04018C"); err == nil {
+		t.Errorf("GetLogin() of non-login did not fail")
+	}
+}
+
+func TestParseLogin(t *testing.T) {
+	field := func(name, designation, kind, value string) interface{} {
+		return map[string]interface{}{"name": name, "designation": designation, "type": kind, "value": value}
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string]interface{}
+		location string
+		want     *Login
+	}{
+		{
+			name: "Designated fields win",
+			data: map[string]interface{}{"fields": []interface{}{
+				field("q", "", "T", "search"),
+				field("email", "username", "E", "joe@example.com"),
+				field("old", "", "P", "hunter1"),
+				field("pass", "password", "P", "hunter2"),
+			}},
+			location: "https://example.com/login",
+			want:     &Login{Username: "joe@example.com", Password: "hunter2", URLs: []string{"https://example.com/login"}},
+		},
+		{
+			name: "No designations",
+			data: map[string]interface{}{"fields": []interface{}{
+				field("remember", "", "C", "✓"),
+				field("user", "", "T", "joe"),
+				field("pass", "", "P", "hunter2"),
+				field("pin", "", "P", "1234"),
+			}},
+			want: &Login{Username: "joe", Password: "hunter2", URLs: []string{}},
+		},
+		{
+			name: "Extra URLs",
+			data: map[string]interface{}{
+				"URLs": []interface{}{
+					map[string]interface{}{"label": "website", "url": "https://example.com/login"},
+					map[string]interface{}{"label": "", "url": "https://m.example.com/"},
+					"not an object",
+				},
+			},
+			location: "https://example.com/login",
+			want:     &Login{URLs: []string{"https://example.com/login", "https://m.example.com/"}},
+		},
+		{
+			name: "Empty",
+			data: map[string]interface{}{},
+			want: &Login{URLs: []string{}},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseLogin(tt.data, tt.location); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseLogin() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
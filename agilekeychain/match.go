@@ -0,0 +1,211 @@
+package agilekeychain
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// parse a URL that may be missing its scheme, like the bare domains in the
+// site field of contents.js.  The scheme of such a URL is left empty.
+func parseLooseURL(rawURL string) (*url.URL, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "//" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("No host in URL %q", rawURL)
+	}
+	return u, nil
+}
+
+// hosts are compared case-insensitively and without a leading "www."
+func normalizeHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
+
+// score how well an item's URL matches the URL being filled in, or 0 if it
+// doesn't match at all.  The host has to match, either exactly or with the
+// target being a subdomain of the item's host; an exact host, a matching
+// path prefix and a matching https scheme each make the match better.
+func urlMatchScore(target *url.URL, candidate *url.URL) int {
+	targetHost := normalizeHost(target.Hostname())
+	candidateHost := normalizeHost(candidate.Hostname())
+
+	score := 0
+	switch {
+	case targetHost == candidateHost:
+		score += 100
+	case strings.HasSuffix(targetHost, "."+candidateHost):
+		score += 50
+	default:
+		return 0
+	}
+
+	if candidate.Scheme != "" {
+		if !strings.EqualFold(candidate.Scheme, target.Scheme) {
+			// never suggest filling an https login into a plain http page
+			if strings.EqualFold(candidate.Scheme, "https") {
+				return 0
+			}
+		} else if strings.EqualFold(candidate.Scheme, "https") {
+			score += 20
+		} else {
+			score += 10
+		}
+	}
+
+	candidatePath := strings.TrimSuffix(candidate.Path, "/")
+	if candidatePath != "" && strings.HasPrefix(target.Path, candidatePath) {
+		score += 5
+	}
+
+	return score
+}
+
+// an item and the URL it's matched on
+type urlIndexEntry struct {
+	item Item
+	url  *url.URL
+}
+
+// the items that have a URL to match on: their location, or their site when
+// they have no location.  Deleted items and unparseable URLs are skipped.
+func (k *AgileKeychain) urlCandidates() []urlIndexEntry {
+	var ret []urlIndexEntry
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		candidateURL := entry.site
+		if item, err := k.loadItemFile(entry.id); err == nil && item.Location != "" {
+			candidateURL = item.Location
+		}
+		if candidateURL == "" {
+			continue
+		}
+
+		candidate, err := parseLooseURL(candidateURL)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, urlIndexEntry{item: entry.item(), url: candidate})
+	}
+	return ret
+}
+
+// the domain a host is registered under: its public suffix, from the Public
+// Suffix List, plus one label, so "login.example.com" gives "example.com" and
+// "login.example.co.uk" gives "example.co.uk".  Hosts that are themselves a
+// public suffix, or that have nothing to go on like "localhost", and IP
+// addresses are returned whole.
+func registrableDomain(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if net.ParseIP(host) != nil {
+		return host
+	}
+
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return domain
+}
+
+// BuildURLIndex indexes the items BestMatchForURL can match by the registrable
+// domain of their URL, returning the item ids for each domain in contents.js
+// order.  Once built, the index is kept and BestMatchForURL only scores the
+// items under the target's domain instead of reading every item file, which
+// matters when serving many lookups.  Reload rebuilds it.
+func (k *AgileKeychain) BuildURLIndex() (map[string][]string, error) {
+	index := make(map[string][]urlIndexEntry)
+	for _, candidate := range k.urlCandidates() {
+		domain := registrableDomain(candidate.url.Hostname())
+		index[domain] = append(index[domain], candidate)
+	}
+	k.urlIndex = index
+
+	ret := make(map[string][]string, len(index))
+	for domain, candidates := range index {
+		for _, candidate := range candidates {
+			ret[domain] = append(ret[domain], candidate.item.ID)
+		}
+	}
+	return ret, nil
+}
+
+// BestMatchForURL returns the item that best matches rawURL for autofill,
+// comparing against each item's location, or its site when it has no
+// location.  A matching host is required; an exact host beats a subdomain
+// match, and a matching scheme and path prefix break ties.  Ties between
+// equally good matches go to the item listed first in contents.js.  Returns
+// ErrItemNotFound if no item matches.  Nothing is decrypted.  Uses the index
+// from BuildURLIndex if there is one.
+func (k *AgileKeychain) BestMatchForURL(rawURL string) (*Item, error) {
+	target, err := parseLooseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []urlIndexEntry
+	if k.urlIndex != nil {
+		candidates = k.urlIndex[registrableDomain(target.Hostname())]
+	} else {
+		candidates = k.urlCandidates()
+	}
+
+	var best *Item
+	bestScore := 0
+
+	for _, candidate := range candidates {
+		if score := urlMatchScore(target, candidate.url); score > bestScore {
+			item := candidate.item
+			best = &item
+			bestScore = score
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("%w: nothing matches %s", ErrItemNotFound, rawURL)
+	}
+	return best, nil
+}
+
+// FindByURL returns the items whose site, from contents.js, has the same
+// registrable domain as rawURL, in contents.js order.  Scheme, port and path
+// are ignored, and so are subdomains on either side, so
+// "https://mail.google.com/x" finds items for "google.com" and
+// "accounts.google.com" alike.  See registrableDomain for how the domain is
+// worked out.  No match is an empty slice, not an error; an unparseable
+// rawURL is an error.  Nothing is decrypted and no item files are read.
+func (k *AgileKeychain) FindByURL(rawURL string) ([]*Item, error) {
+	target, err := parseLooseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	domain := registrableDomain(target.Hostname())
+
+	ret := []*Item{}
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType || entry.site == "" {
+			continue
+		}
+
+		site, err := parseLooseURL(entry.site)
+		if err != nil || registrableDomain(site.Hostname()) != domain {
+			continue
+		}
+
+		item := entry.item()
+		ret = append(ret, &item)
+	}
+	return ret, nil
+}
@@ -0,0 +1,179 @@
+package agilekeychain
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestURLMatchScore(t *testing.T) {
+	mustParse := func(raw string) *url.URL {
+		u, err := parseLooseURL(raw)
+		if err != nil {
+			t.Fatalf("parseLooseURL(%q) error = %v", raw, err)
+		}
+		return u
+	}
+
+	target := mustParse("https://www.example.com/account/login")
+
+	exactHTTPS := urlMatchScore(target, mustParse("https://example.com/account"))
+	exactHTTP := urlMatchScore(mustParse("http://www.example.com/"), mustParse("http://example.com/"))
+	bare := urlMatchScore(target, mustParse("example.com"))
+	parent := urlMatchScore(mustParse("https://mail.example.com/"), mustParse("example.com"))
+	other := urlMatchScore(target, mustParse("https://example.org/"))
+	lookalike := urlMatchScore(target, mustParse("https://notexample.com/"))
+	downgrade := urlMatchScore(mustParse("http://example.com/"), mustParse("https://example.com/"))
+
+	// an exact host with a matching scheme outranks a bare domain, which
+	// outranks a parent domain
+	if !(exactHTTPS > exactHTTP && exactHTTP > bare && bare > parent && parent > 0) {
+		t.Errorf("Scores out of order: https %d, http %d, bare %d, parent domain %d", exactHTTPS, exactHTTP, bare, parent)
+	}
+	if other != 0 || lookalike != 0 {
+		t.Errorf("Different domains matched: %d, %d", other, lookalike)
+	}
+	if downgrade != 0 {
+		t.Errorf("https item matched http page: %d", downgrade)
+	}
+}
+
+func TestBestMatchForURL(t *testing.T) {
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/example1/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://secure.skype.com/account/login", want: "Skype"},
+		{url: "http://www.tumblr.com/dashboard", want: "Tumblr"},
+		{url: "https://www.last.fm/login", want: "Last.fm"},
+		{url: "hulu.com", want: "Hulu"},
+	}
+	for _, indexed := range []bool{false, true} {
+		if indexed {
+			if _, err := keychain.BuildURLIndex(); err != nil {
+				t.Fatalf("BuildURLIndex() error = %v", err)
+			}
+		}
+
+		for _, tt := range tests {
+			item, err := keychain.BestMatchForURL(tt.url)
+			if err != nil {
+				t.Errorf("BestMatchForURL(%q) indexed=%v error = %v", tt.url, indexed, err)
+				continue
+			}
+			if item.Title != tt.want {
+				t.Errorf("BestMatchForURL(%q) indexed=%v = %s, want %s", tt.url, indexed, item.Title, tt.want)
+			}
+		}
+
+		_, err = keychain.BestMatchForURL("https://example.net/")
+		if !errors.Is(err, ErrItemNotFound) {
+			t.Errorf("BestMatchForURL() indexed=%v with no match error = %v, want ErrItemNotFound", indexed, err)
+		}
+	}
+}
+
+func TestRegistrableDomain(t *testing.T) {
+	tests := map[string]string{
+		"www.tumblr.com":      "tumblr.com",
+		"Secure.Skype.COM":    "skype.com",
+		"last.fm":             "last.fm",
+		"localhost":           "localhost",
+		"example.com.":        "example.com",
+		"login.example.co.uk": "example.co.uk",
+		"co.uk":               "co.uk",
+		"alice.github.io":     "alice.github.io",
+		"a.b.blogspot.com":    "b.blogspot.com",
+		"192.168.1.1":         "192.168.1.1",
+		"::1":                 "::1",
+	}
+	for host, want := range tests {
+		if got := registrableDomain(host); got != want {
+			t.Errorf("registrableDomain(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestBuildURLIndex(t *testing.T) {
+	keychainPath := copyFixture(t)
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	index, err := keychain.BuildURLIndex()
+	if err != nil {
+		t.Fatalf("BuildURLIndex() error = %v", err)
+	}
+	if got := index["tumblr.com"]; !reflect.DeepEqual(got, []string{"5ADFF73C09004C448D45565BC4750DE2"}) {
+		t.Errorf("BuildURLIndex()[tumblr.com] = %v, want Tumblr", got)
+	}
+	if _, ok := index["example.net"]; ok {
+		t.Errorf("BuildURLIndex() has example.net before it's added")
+	}
+
+	appendContentsEntries(t, keychainPath,
+		`["0123456789ABCDEF0123456789ABCDEF","webforms.WebForm","Example","example.net",1362350139,"",0,"N"]`)
+	if err := keychain.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	item, err := keychain.BestMatchForURL("https://www.example.net/login")
+	if err != nil || item.ID != "0123456789ABCDEF0123456789ABCDEF" {
+		t.Errorf("BestMatchForURL() after Reload = %v, %v, want the new item", item, err)
+	}
+}
+
+func TestFindByURL(t *testing.T) {
+	keychainPath := copyFixture(t)
+	appendContentsEntries(t, keychainPath,
+		`["0123456789ABCDEF0123456789ABCDE1","webforms.WebForm","Hulu Plus","secure.hulu.com",1362350141,"",0,"N"]`,
+		`["0123456789ABCDEF0123456789ABCDE2","webforms.WebForm","Phish","hulu.com.example.net",1362350142,"",0,"N"]`,
+		`["0123456789ABCDEF0123456789ABCDE3","webforms.WebForm","My Bank","login.mybank.co.uk",1362350143,"",0,"N"]`,
+		`["0123456789ABCDEF0123456789ABCDE4","webforms.WebForm","Pages","alice.github.io",1362350144,"",0,"N"]`,
+	)
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		rawURL string
+		want   []string
+	}{
+		{"Exact host", "hulu.com", []string{"Hulu", "Hulu Plus"}},
+		{"Scheme, www and path ignored", "https://www.hulu.com/watch/123", []string{"Hulu", "Hulu Plus"}},
+		{"Subdomain", "http://mail.skype.com", []string{"Skype"}},
+		{"Other domain", "https://example.org/", []string{}},
+		{"Lookalike subdomain goes by its real domain", "https://example.net/", []string{"Phish"}},
+		{"Subdomain under a multi-label public suffix", "https://www.mybank.co.uk/", []string{"My Bank"}},
+		{"Other domain under the same public suffix", "https://evil.co.uk/", []string{}},
+		{"Other site on a shared hosting suffix", "https://mallory.github.io/", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := keychain.FindByURL(tt.rawURL)
+			if err != nil {
+				t.Fatalf("FindByURL(%q) error = %v", tt.rawURL, err)
+			}
+			got := []string{}
+			for _, item := range items {
+				got = append(got, item.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindByURL(%q) = %q, want %q", tt.rawURL, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := keychain.FindByURL("http://"); err == nil {
+		t.Error("FindByURL() of a URL without a host error = nil, want error")
+	}
+}
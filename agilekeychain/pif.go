@@ -0,0 +1,124 @@
+package agilekeychain
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+)
+
+// 1Password writes this line between the records of a .1pif data file
+const pifSeparator = "***5642bee8-a5ff-11dc-8314-0800200c9a66***"
+
+// ExportPIFBundle writes every item in the keychain to destDir as a 1Password
+// Interchange Format bundle, with one JSON record per item in
+// destDir/data.1pif.  destDir must not already exist; it and everything in it
+// are created with owner-only permissions, since the bundle holds the
+// decrypted secrets.  Deleted items aren't exported.
+//
+// Attachments aren't exported.  There is no keychain with attachments to check
+// their on-disk layout against, so the bundle has no attachments directory.
+func (k *AgileKeychain) ExportPIFBundle(destDir string, passphrase string) error {
+	err := k.loadEncryptionKeys(passphrase)
+	if err != nil {
+		return err
+	}
+
+	err = os.Mkdir(destDir, 0700)
+	if err != nil {
+		return err
+	}
+
+	dataFile, err := os.OpenFile(path.Join(destDir, "data.1pif"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = k.writePIF(dataFile)
+	if closeErr := dataFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// write a .1pif record for each item in the keychain to w
+func (k *AgileKeychain) writePIF(w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		raw, err := k.loadRawItemFile(entry.id)
+		if err != nil {
+			return err
+		}
+
+		err = k.withDecryptedItem(entry.id, func(item *itemFile, plaintext []byte) error {
+			record, err := pifRecord(raw, plaintext)
+			if err != nil {
+				return fmt.Errorf("Failed to export item %s: %v", entry.id, err)
+			}
+			defer clear(record)
+
+			_, err = out.Write(record)
+			if err == nil {
+				_, err = out.WriteString("\n" + pifSeparator + "\n")
+			}
+			if err == nil {
+				// don't let secrets sit in the buffer any longer than needed
+				err = out.Flush()
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return out.Flush()
+}
+
+// turn a raw item file into a .1pif record: the encrypted payload and key id
+// are replaced with the decrypted secureContents, and the security level and
+// contents hash move from openContents to the top level
+func pifRecord(raw map[string]interface{}, plaintext []byte) ([]byte, error) {
+	record := make(map[string]interface{}, len(raw))
+	for key, value := range raw {
+		switch key {
+		case "encrypted", "keyID", "openContents":
+			continue
+		}
+		record[key] = value
+	}
+
+	if openContents, ok := raw["openContents"].(map[string]interface{}); ok {
+		rest := make(map[string]interface{}, len(openContents))
+		for key, value := range openContents {
+			switch key {
+			case "securityLevel", "contentsHash":
+				record[key] = value
+			default:
+				rest[key] = value
+			}
+		}
+		if len(rest) > 0 {
+			record["openContents"] = rest
+		}
+	}
+
+	if !json.Valid(plaintext) {
+		return nil, errors.New("Decrypted contents aren't valid JSON")
+	}
+	record["secureContents"] = json.RawMessage(plaintext)
+
+	return json.Marshal(record)
+}
@@ -0,0 +1,83 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// read a .1pif data file back into its records
+func readPIF(t *testing.T, filePath string) []map[string]interface{} {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read data.1pif: %v", err)
+	}
+
+	var records []map[string]interface{}
+	for _, chunk := range strings.Split(string(data), "\n"+pifSeparator+"\n") {
+		if chunk == "" {
+			continue
+		}
+
+		var record map[string]interface{}
+		err = json.Unmarshal([]byte(chunk), &record)
+		if err != nil {
+			t.Fatalf("Failed to parse 1pif record %q: %v", chunk, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestExportPIFBundle(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	bundle := path.Join(t.TempDir(), "export.1pif")
+	err = keychain.ExportPIFBundle(bundle, "1Password")
+	if err != nil {
+		t.Fatalf("ExportPIFBundle() error = %v", err)
+	}
+
+	records := readPIF(t, path.Join(bundle, "data.1pif"))
+	if len(records) != keychain.Length()-1 {
+		t.Fatalf("Got %d records, want %d (everything but the tombstone)", len(records), keychain.Length()-1)
+	}
+
+	for _, record := range records {
+		id, _ := record["uuid"].(string)
+
+		want, err := keychain.DecryptItem(id)
+		if err != nil {
+			t.Fatalf("DecryptItem(%s) error = %v", id, err)
+		}
+		if !reflect.DeepEqual(record["secureContents"], map[string]interface{}(want)) {
+			t.Errorf("Record %s secureContents = %v, want %v", id, record["secureContents"], want)
+		}
+
+		if _, ok := record["encrypted"]; ok {
+			t.Errorf("Record %s still has its encrypted payload", id)
+		}
+		if level := record["securityLevel"]; level != "SL3" && level != "SL5" {
+			t.Errorf("Record %s securityLevel = %v", id, level)
+		}
+	}
+
+	if _, err := os.Stat(path.Join(bundle, "attachments")); !os.IsNotExist(err) {
+		t.Errorf("Bundle has an attachments directory: %v", err)
+	}
+
+	// refuse to write into an existing directory
+	err = keychain.ExportPIFBundle(bundle, "1Password")
+	if err == nil {
+		t.Errorf("ExportPIFBundle() into an existing directory succeeded")
+	}
+}
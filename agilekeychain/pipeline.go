@@ -0,0 +1,132 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+)
+
+// Item files are read through a pipeline of stages, in this order:
+//
+//  1. open: the data/default/<id>.1password file is read from the vault
+//  2. decode: the file's bytes pass through each ItemStage in turn, first the
+//     built-in stages (gzip decompression, then base64 decoding) and then any
+//     added with WithItemStage, in the order they were added
+//  3. parse: the result is parsed as item JSON
+//  4. decrypt: the item's encrypted payload is decoded (see
+//     decodeEncryptedPayload) and decrypted with the item's key
+//
+// Only the decode stage is pluggable; it's where alternate on-disk encodings
+// of an item file are turned back into the JSON 1Password writes.
+
+// ItemStage transforms the raw bytes of an item file before they are parsed.
+// A stage that doesn't recognize its input should return it unchanged.
+type ItemStage func(id string, data []byte) ([]byte, error)
+
+// stages run on every item file before any added with WithItemStage
+var builtinItemStages = []ItemStage{gunzipStage, base64Stage}
+
+// WithItemStage adds a stage to the decode step of the item pipeline, after
+// the built-in stages and any stages added before it
+func WithItemStage(stage ItemStage) Option {
+	return func(k *AgileKeychain) {
+		k.itemStages = append(k.itemStages, stage)
+	}
+}
+
+// open stage: read the item file for id from disk
+func (k *AgileKeychain) openItemFile(id string) ([]byte, error) {
+	err := validateItemID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.readFile(path.Join(k.dataDir(), id+".1password"))
+}
+
+// decode stage: run the item file through every ItemStage
+func (k *AgileKeychain) decodeItemFile(id string, data []byte) ([]byte, error) {
+	var err error
+
+	for _, stages := range [][]ItemStage{builtinItemStages, k.itemStages} {
+		for _, stage := range stages {
+			data, err = stage(id, data)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to decode item %s: %v", id, err)
+			}
+		}
+	}
+	return data, nil
+}
+
+// parse stage: unmarshal the decoded item file
+func parseItemFile(id string, data []byte) (*itemFile, error) {
+	var item itemFile
+	err := json.Unmarshal(data, &item)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse item %s: %v", id, err)
+	}
+
+	return &item, nil
+}
+
+// load an item file through the open and decode stages and unmarshal it
+// generically, keeping the fields that itemFile doesn't model
+func (k *AgileKeychain) loadRawItemFile(id string) (map[string]interface{}, error) {
+	data, err := k.openItemFile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = k.decodeItemFile(id, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse item %s: %v", id, err)
+	}
+	return raw, nil
+}
+
+// decompress gzipped item files, recognized by the gzip magic number
+func gunzipStage(id string, data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		return data, nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+// Some backup tools store item files base64-encoded, possibly with line
+// breaks.  Files that are already JSON are left alone, as is anything that
+// doesn't decode to JSON, so that it fails to parse as itself.
+func base64Stage(id string, data []byte) ([]byte, error) {
+	if json.Valid(data) {
+		return data, nil
+	}
+
+	stripped := bytes.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, data)
+
+	decoded, err := decodeBase64(string(stripped))
+	if err != nil || !json.Valid(decoded) {
+		return data, nil
+	}
+	return decoded, nil
+}
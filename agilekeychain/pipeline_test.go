@@ -0,0 +1,148 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+// rewrite the Hulu item file in a copy of the fixture with transform
+func rewriteHuluItem(t *testing.T, transform func([]byte) []byte) string {
+	t.Helper()
+
+	keychainPath := copyFixture(t)
+	itemPath := path.Join(keychainPath, "data", "default", "13C8E12AC8E54B1F873BAB0824E521BC.1password")
+
+	data, err := ioutil.ReadFile(itemPath)
+	if err != nil {
+		t.Fatalf("Failed to read item: %v", err)
+	}
+
+	err = ioutil.WriteFile(itemPath, transform(data), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write item: %v", err)
+	}
+	return keychainPath
+}
+
+func TestItemPipeline_Gzip(t *testing.T) {
+	keychainPath := rewriteHuluItem(t, func(data []byte) []byte {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write(data)
+		w.Close()
+		return buf.Bytes()
+	})
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	data, err := keychain.DecryptItem("13C8E12AC8E54B1F873BAB0824E521BC")
+	if err != nil {
+		t.Fatalf("DecryptItem() of gzipped item error = %v", err)
+	}
+	if _, ok := data["fields"]; !ok {
+		t.Errorf("Decrypted gzipped item has no fields: %v", data)
+	}
+}
+
+func TestItemPipeline_CustomStage(t *testing.T) {
+	header := []byte("PASSYNC-HEADER\n")
+	keychainPath := rewriteHuluItem(t, func(data []byte) []byte {
+		return append(append([]byte{}, header...), data...)
+	})
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	_, err = keychain.DecryptItem("13C8E12AC8E54B1F873BAB0824E521BC")
+	if err == nil {
+		t.Errorf("DecryptItem() of item with header succeeded without a stage to strip it")
+	}
+
+	var seen []string
+	stripHeader := func(id string, data []byte) ([]byte, error) {
+		seen = append(seen, id)
+		return bytes.TrimPrefix(data, header), nil
+	}
+
+	keychain, err = NewAgileKeychain(keychainPath, "1Password", WithItemStage(stripHeader))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	_, err = keychain.DecryptItem("13C8E12AC8E54B1F873BAB0824E521BC")
+	if err != nil {
+		t.Errorf("DecryptItem() with custom stage error = %v", err)
+	}
+	if len(seen) != 1 || seen[0] != "13C8E12AC8E54B1F873BAB0824E521BC" {
+		t.Errorf("Custom stage saw ids %v", seen)
+	}
+
+	stageErr := errors.New("stage failed")
+	failing := func(id string, data []byte) ([]byte, error) {
+		return nil, stageErr
+	}
+
+	keychain, err = NewAgileKeychain(keychainPath, "1Password", WithItemStage(failing))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	_, err = keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C")
+	if err == nil {
+		t.Errorf("DecryptItem() with failing stage did not fail")
+	}
+}
+
+func TestItemPipeline_Base64(t *testing.T) {
+	// the fixture's Tumblr item file is base64 with 76 column lines, and its
+	// Hulu item file plain JSON
+	keychain, err := NewAgileKeychain("../testdata/agilekeychain/base64wrapped/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	for _, id := range []string{"5ADFF73C09004C448D45565BC4750DE2", "13C8E12AC8E54B1F873BAB0824E521BC"} {
+		data, err := keychain.DecryptItem(id)
+		if err != nil {
+			t.Errorf("DecryptItem(%s) error = %v", id, err)
+			continue
+		}
+		if _, ok := data["fields"]; !ok {
+			t.Errorf("Decrypted item %s has no fields: %v", id, data)
+		}
+	}
+
+	login, err := keychain.GetLogin("5ADFF73C09004C448D45565BC4750DE2")
+	if err != nil || login.URLs[0] != "http://www.tumblr.com/login" {
+		t.Errorf("GetLogin() of base64 item = %+v, %v", login, err)
+	}
+}
+
+func TestBase64Stage(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "JSON", data: `{"a":1}`, want: `{"a":1}`},
+		{name: "Base64 JSON", data: "eyJhIjox\nfQ==\n", want: `{"a":1}`},
+		// "not json" encoded
+		{name: "Base64 of something else", data: "bm90IGpzb24=", want: "bm90IGpzb24="},
+		{name: "Garbage", data: "{not json", want: "{not json"},
+	}
+	for _, tt := range tests {
+		got, err := base64Stage("id", []byte(tt.data))
+		if err != nil || string(got) != tt.want {
+			t.Errorf("%s: base64Stage() = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package agilekeychain
+
+import (
+	"crypto/aes"
+	"fmt"
+)
+
+// check that blob is an OpenSSL salted blob with a whole number of AES blocks
+// of ciphertext, without decrypting it
+func checkSalted(blob []byte) error {
+	if len(blob) < 16 {
+		return fmt.Errorf("Blob too short for an OpenSSL salt: %d bytes", len(blob))
+	}
+
+	_, ciphertext, err := extractSalt(blob)
+	if err != nil {
+		return err
+	}
+
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return fmt.Errorf("Ciphertext length %d is not a positive multiple of the AES block size", len(ciphertext))
+	}
+	return nil
+}
+
+// PrecheckSalts checks that the data and validation blob of every key in
+// encryptionKeys.js is a well-formed OpenSSL salted blob, without decrypting
+// anything.  This catches structurally broken keychains up front, with an
+// error naming the blob at fault, rather than as a confusing decryption
+// failure later.  See PrecheckItemSalts for the item files.
+func (k *AgileKeychain) PrecheckSalts() error {
+	raw, err := k.readRawEncryptionKeys()
+	if err != nil {
+		return err
+	}
+
+	for _, rawKey := range raw.List {
+		blobs := []struct {
+			name    string
+			encoded string
+		}{
+			{"data", rawKey.Data},
+			{"validation", rawKey.Validation},
+		}
+
+		for _, b := range blobs {
+			blob, err := decodeBase64(b.encoded)
+			if err != nil {
+				return fmt.Errorf("Key %s %s: %v", rawKey.Identifier, b.name, err)
+			}
+
+			err = checkSalted(blob)
+			if err != nil {
+				return fmt.Errorf("Key %s %s: %v", rawKey.Identifier, b.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// PrecheckItemSalts checks that the encrypted payload of every item in the
+// keychain is a well-formed OpenSSL salted blob, without decrypting anything.
+// Every item file is read.  Failures are reported together in an ItemErrors.
+func (k *AgileKeychain) PrecheckItemSalts() error {
+	failures := ItemErrors{}
+
+	for _, entry := range k.contents {
+		item, err := k.loadItemFile(entry.id)
+		if err != nil {
+			failures[entry.id] = err
+			continue
+		}
+
+		blob, err := decodeEncryptedPayload(item.Encrypted)
+		if err == nil {
+			err = checkSalted(blob)
+		}
+		if err != nil {
+			failures[entry.id] = err
+		}
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+	return nil
+}
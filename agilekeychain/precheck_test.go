@@ -0,0 +1,92 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestCheckSalted(t *testing.T) {
+	tests := []struct {
+		name    string
+		blob    []byte
+		wantErr bool
+	}{
+		{"Valid", append([]byte("Salted__12345678"), make([]byte, 32)...), false},
+		{"Empty", nil, true},
+		{"Short", []byte("Salted__"), true},
+		{"No magic", make([]byte, 48), true},
+		{"No ciphertext", []byte("Salted__12345678"), true},
+		{"Partial block", append([]byte("Salted__12345678"), make([]byte, 20)...), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSalted(tt.blob)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkSalted() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrecheckSalts(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	err = keychain.PrecheckSalts()
+	if err != nil {
+		t.Errorf("PrecheckSalts() on pristine fixture error = %v", err)
+	}
+	err = keychain.PrecheckItemSalts()
+	if err != nil {
+		t.Errorf("PrecheckItemSalts() on pristine fixture error = %v", err)
+	}
+
+	// truncate the SL5 key's validation blob to just its magic
+	keysPath := path.Join(keychainPath, "data", "default", "encryptionKeys.js")
+	keys, err := ioutil.ReadFile(keysPath)
+	if err != nil {
+		t.Fatalf("Failed to read keys: %v", err)
+	}
+	raw, err := keychain.readRawEncryptionKeys()
+	if err != nil {
+		t.Fatalf("readRawEncryptionKeys() error = %v", err)
+	}
+	var validation, id string
+	for _, rawKey := range raw.List {
+		if rawKey.Identifier == raw.SL5 {
+			validation, id = rawKey.Validation, rawKey.Identifier
+		}
+	}
+	truncated := base64.StdEncoding.EncodeToString([]byte("Salted__"))
+	keys = bytes.Replace(keys, []byte(strings.TrimSuffix(validation, "\x00")), []byte(truncated), 1)
+	err = ioutil.WriteFile(keysPath, keys, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write keys: %v", err)
+	}
+
+	err = keychain.PrecheckSalts()
+	if err == nil || !strings.Contains(err.Error(), id+" validation") {
+		t.Errorf("PrecheckSalts() error = %v, want one naming key %s validation", err, id)
+	}
+
+	// and corrupt an item's payload
+	itemPath := path.Join(keychainPath, "data", "default", "13C8E12AC8E54B1F873BAB0824E521BC.1password")
+	err = ioutil.WriteFile(itemPath, []byte(`{"uuid":"13C8E12AC8E54B1F873BAB0824E521BC","encrypted":"AAAA"}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write item: %v", err)
+	}
+
+	err = keychain.PrecheckItemSalts()
+	itemErrs, ok := err.(ItemErrors)
+	if !ok || len(itemErrs) != 1 || itemErrs["13C8E12AC8E54B1F873BAB0824E521BC"] == nil {
+		t.Errorf("PrecheckItemSalts() error = %v, want one failure for the corrupted item", err)
+	}
+}
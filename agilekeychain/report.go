@@ -0,0 +1,148 @@
+package agilekeychain
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+type reportOptions struct {
+	passphrase string
+}
+
+// ReportOption configures WriteReport
+type ReportOption func(*reportOptions)
+
+// ReportPassphrase makes WriteReport decrypt every item with passphrase and
+// include the full SecurityAudit findings, such as reused passwords and
+// expired cards, instead of only those that can be found from metadata
+func ReportPassphrase(passphrase string) ReportOption {
+	return func(o *reportOptions) {
+		o.passphrase = passphrase
+	}
+}
+
+// WriteReport writes an overview of the keychain to w as aligned text: item
+// counts by type and security level, the range of item dates, whether each
+// key validated, and audit findings.  Without ReportPassphrase nothing is
+// decrypted and the findings are limited to plain-http URLs, keys with too few
+// PBKDF2 iterations, and duplicate items.
+func (k *AgileKeychain) WriteReport(w io.Writer, opts ...ReportOption) error {
+	var options reportOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	byType := make(map[string]int)
+	byLevel := make(map[string]int)
+	var oldest, newest Item
+	var findings []Finding
+	deleted := 0
+
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			deleted++
+			continue
+		}
+
+		byType[entry.entryType]++
+
+		if oldest.ID == "" || entry.date.Before(oldest.Date) {
+			oldest = entry.item()
+		}
+		if newest.ID == "" || entry.date.After(newest.Date) {
+			newest = entry.item()
+		}
+
+		level := "unknown"
+		item, err := k.loadItemFile(entry.id)
+		if err == nil {
+			if key, err := k.keyForItem(item); err == nil {
+				level = key.level.String()
+			}
+
+			if options.passphrase == "" && isInsecureURL(item.Location) {
+				findings = append(findings, insecureURLFinding(entry, item.Location))
+			}
+		}
+		byLevel[level]++
+	}
+
+	var auditErr error
+	if options.passphrase != "" {
+		var report AuditReport
+		report, auditErr = k.SecurityAudit(options.passphrase)
+		if _, ok := auditErr.(ItemErrors); auditErr != nil && !ok {
+			return auditErr
+		}
+		findings = report.Findings
+	} else {
+		raw, err := k.readRawEncryptionKeys()
+		if err != nil {
+			return err
+		}
+		for _, rawKey := range raw.List {
+			if rawKey.Iterations < minRecommendedIterations {
+				findings = append(findings, weakIterationsFinding(rawKey.Identifier, rawKey.Iterations, nil))
+			}
+		}
+		sortFindings(findings)
+	}
+
+	fmt.Fprintf(tw, "Keychain:\t%s\n", k.baseDir)
+	fmt.Fprintf(tw, "Items:\t%d (%d deleted)\n", len(k.contents)-deleted, deleted)
+	if oldest.ID != "" {
+		fmt.Fprintf(tw, "Dates:\t%s to %s\n", oldest.Date.Format("2006-01-02"), newest.Date.Format("2006-01-02"))
+	}
+
+	fmt.Fprintf(tw, "\nBy type:\n")
+	writeCounts(tw, byType)
+
+	fmt.Fprintf(tw, "\nBy security level:\n")
+	writeCounts(tw, byLevel)
+
+	fmt.Fprintf(tw, "\nKey validation:\n")
+	status := k.ValidationStatus()
+	for _, level := range sortedKeys(status) {
+		result := "ok"
+		if status[level] != nil {
+			result = status[level].Error()
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", level, result)
+	}
+
+	fmt.Fprintf(tw, "\nFindings:\n")
+	for _, finding := range findings {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", finding.Severity, finding.Kind, finding.Detail)
+	}
+	duplicates := k.DuplicateItems()
+	for _, group := range duplicates {
+		fmt.Fprintf(tw, "  %s\t%s\t%d items titled %q for %q\n", SeverityLow, "duplicate", len(group), group[0].Title, group[0].Site)
+	}
+	if len(findings) == 0 && len(duplicates) == 0 {
+		fmt.Fprintf(tw, "  none\n")
+	}
+	if itemErrs, ok := auditErr.(ItemErrors); ok {
+		fmt.Fprintf(tw, "  \t\t%d items couldn't be decrypted and weren't audited\n", len(itemErrs))
+	}
+
+	return tw.Flush()
+}
+
+func writeCounts(w io.Writer, counts map[string]int) {
+	for _, name := range sortedKeys(counts) {
+		fmt.Fprintf(w, "  %s\t%d\n", name, counts[name])
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,52 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteReport(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = keychain.WriteReport(&buf)
+	if err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+	report := buf.String()
+
+	for _, want := range []string{
+		"Items:     18 (1 deleted)\n",
+		"Dates:     2013-03-03 to 2013-03-03\n",
+		"  webforms.WebForm              8\n",
+		"  SL3  3\n  SL5  15\n",
+		"Key validation:\n  SL3  ok\n  SL5  ok\n",
+		"insecure-url     Hulu uses an unencrypted URL: http://www.hulu.com/\n",
+		"weak-iterations  Key 91F7E2D5E3E54447819ABDD84CFB27A2 uses 10000 PBKDF2 iterations",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("WriteReport() output missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "expired") {
+		t.Errorf("WriteReport() without a passphrase reported secret-dependent findings:\n%s", report)
+	}
+
+	buf.Reset()
+	err = keychain.WriteReport(&buf, ReportPassphrase("1Password"))
+	if err != nil {
+		t.Fatalf("WriteReport() with passphrase error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "expired          Chase VISA ***4356 expired 2019-06\n") {
+		t.Errorf("WriteReport() with passphrase missing expired card:\n%s", buf.String())
+	}
+
+	err = keychain.WriteReport(&buf, ReportPassphrase("wrong"))
+	if err == nil {
+		t.Errorf("WriteReport() with wrong passphrase succeeded")
+	}
+}
@@ -0,0 +1,231 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ExportFormat is a format RoundTripCheck can export a keychain to
+type ExportFormat int
+
+const (
+	// ExportFormatAgileKeychain is a new AgileKeychain, as ExportFolder writes
+	ExportFormatAgileKeychain ExportFormat = iota
+	// ExportFormatPIF is the 1Password Interchange Format, as ExportPIFBundle
+	// writes
+	ExportFormatPIF
+)
+
+func (f ExportFormat) String() string {
+	switch f {
+	case ExportFormatAgileKeychain:
+		return "AgileKeychain"
+	case ExportFormatPIF:
+		return "1PIF"
+	default:
+		return fmt.Sprintf("ExportFormat(%d)", int(f))
+	}
+}
+
+// an item as read back from an export
+type roundTripItem struct {
+	title    string
+	contents map[string]interface{}
+}
+
+// RoundTripCheck exports every item in the keychain to format, reads the
+// export back, and compares each item's title and decrypted contents with the
+// original, to check that a migration through format loses nothing.  The
+// export is made to a temporary directory, encrypted under passphrase where
+// the format is encrypted, and removed afterwards.
+//
+// Items that are missing from the export, or whose title or contents
+// changed, are returned as ItemErrors naming the fields that differ, e.g.
+// "fields[1].value".  Deleted items aren't exported, so aren't compared.
+func (k *AgileKeychain) RoundTripCheck(format ExportFormat, passphrase string) error {
+	err := k.loadEncryptionKeys(passphrase)
+	if err != nil {
+		return err
+	}
+
+	var reimported map[string]roundTripItem
+	switch format {
+	case ExportFormatAgileKeychain:
+		reimported, err = k.roundTripAgileKeychain(passphrase)
+	case ExportFormatPIF:
+		reimported, err = k.roundTripPIF()
+	default:
+		return fmt.Errorf("Unknown export format %v", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	errs := make(ItemErrors)
+	exported := make(map[string]bool)
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+		exported[entry.id] = true
+
+		got, ok := reimported[entry.id]
+		if !ok {
+			errs[entry.id] = fmt.Errorf("missing from %v export", format)
+			continue
+		}
+
+		want, err := k.DecryptItem(entry.id)
+		if err != nil {
+			errs[entry.id] = err
+			continue
+		}
+
+		var differ []string
+		if got.title != entry.title {
+			differ = append(differ, "title")
+		}
+		differ = append(differ, diffValues("", want, got.contents)...)
+		if len(differ) > 0 {
+			errs[entry.id] = fmt.Errorf("%s differ after %v round trip", strings.Join(differ, ", "), format)
+		}
+	}
+
+	for id := range reimported {
+		if !exported[id] {
+			errs[id] = fmt.Errorf("unexpected item in %v export", format)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// export the keychain to a new keychain in a temporary directory, open it
+// with passphrase and decrypt its items
+func (k *AgileKeychain) roundTripAgileKeychain(passphrase string) (map[string]roundTripItem, error) {
+	tempDir, err := ioutil.TempDir("", "passync-roundtrip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	members := make(map[string]bool, len(k.contents))
+	for _, entry := range k.contents {
+		if entry.entryType != tombstoneType {
+			members[entry.id] = true
+		}
+	}
+
+	destPath := path.Join(tempDir, "1Password.agilekeychain")
+	err = k.exportKeychain(members, destPath, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	exported, err := NewAgileKeychain(destPath, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer exported.Close()
+
+	ret := make(map[string]roundTripItem, len(members))
+	for _, item := range exported.List() {
+		contents, err := exported.DecryptItem(item.ID)
+		if err != nil {
+			return nil, err
+		}
+		ret[item.ID] = roundTripItem{title: item.Title, contents: contents}
+	}
+	return ret, nil
+}
+
+// export the keychain as .1pif records in memory and parse them back
+func (k *AgileKeychain) roundTripPIF() (map[string]roundTripItem, error) {
+	var buf bytes.Buffer
+	defer func() {
+		clear(buf.Bytes())
+	}()
+
+	err := k.writePIF(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]roundTripItem)
+	for _, record := range strings.Split(buf.String(), "\n"+pifSeparator+"\n") {
+		if record == "" {
+			continue
+		}
+
+		var parsed struct {
+			UUID           string                 `json:"uuid"`
+			Title          string                 `json:"title"`
+			SecureContents map[string]interface{} `json:"secureContents"`
+		}
+		err = json.Unmarshal([]byte(record), &parsed)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse .1pif record: %v", err)
+		}
+		ret[parsed.UUID] = roundTripItem{title: parsed.Title, contents: parsed.SecureContents}
+	}
+	return ret, nil
+}
+
+// the paths, in the style of "fields[1].value", at which want and got
+// differ, sorted
+func diffValues(at string, want interface{}, got interface{}) []string {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			break
+		}
+		var ret []string
+		for key := range w {
+			ret = append(ret, diffValues(joinPath(at, key), w[key], g[key])...)
+		}
+		for key := range g {
+			if _, ok := w[key]; !ok {
+				ret = append(ret, joinPath(at, key))
+			}
+		}
+		sort.Strings(ret)
+		return ret
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			break
+		}
+		var ret []string
+		for ix := range w {
+			ret = append(ret, diffValues(fmt.Sprintf("%s[%d]", at, ix), w[ix], g[ix])...)
+		}
+		return ret
+	default:
+		if reflect.DeepEqual(want, got) {
+			return nil
+		}
+	}
+
+	if at == "" {
+		return []string{"contents"}
+	}
+	return []string{at}
+}
+
+func joinPath(at string, key string) string {
+	if at == "" {
+		return key
+	}
+	return at + "." + key
+}
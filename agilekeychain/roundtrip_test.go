@@ -0,0 +1,64 @@
+package agilekeychain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRoundTripCheck(t *testing.T) {
+	keychain, err := NewAgileKeychain(copyFixture(t), "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	for _, format := range []ExportFormat{ExportFormatAgileKeychain, ExportFormatPIF} {
+		if err := keychain.RoundTripCheck(format, "1Password"); err != nil {
+			t.Errorf("RoundTripCheck(%v) error = %v", format, err)
+		}
+	}
+}
+
+func TestRoundTripCheck_Errors(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	if err := keychain.RoundTripCheck(ExportFormatPIF, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("RoundTripCheck(wrong passphrase) error = %v, want ErrWrongPassphrase", err)
+	}
+	if err := keychain.RoundTripCheck(ExportFormat(42), "1Password"); err == nil {
+		t.Error("RoundTripCheck(unknown format) error = nil, want error")
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	want := map[string]interface{}{
+		"notesPlain": "hello",
+		"fields": []interface{}{
+			map[string]interface{}{"name": "username", "value": "bob"},
+			map[string]interface{}{"name": "password", "value": "hunter2"},
+		},
+	}
+
+	if got := diffValues("", want, want); got != nil {
+		t.Errorf("diffValues(same) = %v, want nil", got)
+	}
+
+	got := map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{"name": "username", "value": "bob"},
+			map[string]interface{}{"name": "password", "value": "hunter3"},
+		},
+		"extra": true,
+	}
+	wantDiff := []string{"extra", "fields[1].value", "notesPlain"}
+	if diff := diffValues("", want, got); !reflect.DeepEqual(diff, wantDiff) {
+		t.Errorf("diffValues() = %v, want %v", diff, wantDiff)
+	}
+
+	if diff := diffValues("", want, nil); !reflect.DeepEqual(diff, []string{"contents"}) {
+		t.Errorf("diffValues(nil) = %v, want [contents]", diff)
+	}
+}
@@ -0,0 +1,60 @@
+package agilekeychain
+
+// The item schemas DetectItemSchema recognizes.  1Password has laid out the
+// decrypted contents of item files in several ways over the years, and an
+// item's type doesn't say which one it uses.
+const (
+	// ItemSchemaFields is the webform layout: a top-level "fields" array
+	// whose entries carry a name, type, value and, for the username and
+	// password, a "designation" naming their role
+	ItemSchemaFields = "fields"
+	// ItemSchemaSections is the newer layout: a top-level "sections" array,
+	// each section holding "fields" entries keyed k (kind), n (name), t
+	// (label) and v (value)
+	ItemSchemaSections = "sections"
+	// ItemSchemaFieldsAndSections is a webform that also has sections, as
+	// logins edited by newer versions of 1Password do
+	ItemSchemaFieldsAndSections = "fields+sections"
+	// ItemSchemaFlat is the original layout, with each field a top-level
+	// property such as "ccnum" or "notesPlain"
+	ItemSchemaFlat = "flat"
+	// ItemSchemaTombstone is a deleted item, which has no contents.  This is
+	// detected from contents.js without decrypting anything.
+	ItemSchemaTombstone = "tombstone"
+)
+
+// DetectItemSchema reports which layout, one of the ItemSchema constants,
+// the item with the given id uses, so that a parser knows which to expect.
+// Tombstones are recognized from their metadata; anything else is decrypted
+// to inspect its structure.
+func (k *AgileKeychain) DetectItemSchema(id string) (string, error) {
+	item, err := k.GetItem(id)
+	if err != nil {
+		return "", err
+	}
+	if item.Type == tombstoneType {
+		return ItemSchemaTombstone, nil
+	}
+
+	data, err := k.DecryptItem(id)
+	if err != nil {
+		return "", err
+	}
+	return detectItemSchema(data), nil
+}
+
+func detectItemSchema(data map[string]interface{}) string {
+	_, hasFields := data["fields"].([]interface{})
+	_, hasSections := data["sections"].([]interface{})
+
+	switch {
+	case hasFields && hasSections:
+		return ItemSchemaFieldsAndSections
+	case hasFields:
+		return ItemSchemaFields
+	case hasSections:
+		return ItemSchemaSections
+	default:
+		return ItemSchemaFlat
+	}
+}
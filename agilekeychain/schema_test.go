@@ -0,0 +1,60 @@
+package agilekeychain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDetectItemSchema(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		// Tumblr login
+		{id: "5ADFF73C09004C448D45565BC4750DE2", want: ItemSchemaFields},
+		// credit card
+		{id: "D06307ADA44C4031BA2FF4B174DE79CB", want: ItemSchemaFlat},
+		// secure note
+		{id: "D1820AA8CB534AC6A4B5A2C0263FD3B2", want: ItemSchemaFlat},
+		{id: "3A47A0E3FEE948ADA9028FF0DA053CDB", want: ItemSchemaTombstone},
+	}
+	for _, tt := range tests {
+		got, err := keychain.DetectItemSchema(tt.id)
+		if err != nil || got != tt.want {
+			t.Errorf("DetectItemSchema(%s) = %q, %v, want %q", tt.id, got, err, tt.want)
+		}
+	}
+
+	_, err = keychain.DetectItemSchema("00000000000000000000000000000000")
+	if !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("DetectItemSchema() of unknown id error = %v, want ErrItemNotFound", err)
+	}
+}
+
+func TestDetectItemSchema_Layouts(t *testing.T) {
+	fields := []interface{}{map[string]interface{}{"designation": "username", "value": "joe"}}
+	sections := []interface{}{map[string]interface{}{"name": "", "fields": []interface{}{}}}
+
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		want string
+	}{
+		{name: "Fields", data: map[string]interface{}{"fields": fields}, want: ItemSchemaFields},
+		{name: "Sections", data: map[string]interface{}{"sections": sections}, want: ItemSchemaSections},
+		{name: "Both", data: map[string]interface{}{"fields": fields, "sections": sections}, want: ItemSchemaFieldsAndSections},
+		{name: "Flat", data: map[string]interface{}{"ccnum": "4111111111111111"}, want: ItemSchemaFlat},
+		{name: "Empty", data: map[string]interface{}{}, want: ItemSchemaFlat},
+		{name: "Fields not an array", data: map[string]interface{}{"fields": "x"}, want: ItemSchemaFlat},
+	}
+	for _, tt := range tests {
+		if got := detectItemSchema(tt.data); got != tt.want {
+			t.Errorf("%s: detectItemSchema() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
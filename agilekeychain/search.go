@@ -0,0 +1,160 @@
+package agilekeychain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// WithTitleNormalizer overrides the function applied to item titles, and to
+// the titles searched for, before they're compared.
+//
+// Titles are stored as whatever Unicode the app that saved them produced, and
+// macOS tends to produce decomposed (NFD) text where other platforms produce
+// composed (NFC), so a "café" typed on one may not equal a "café" saved on the
+// other.  By default titles are put into NFC, which makes the two match;
+// this option is only needed for some other normal form, such as NFKC.
+func WithTitleNormalizer(normalizer func(string) string) Option {
+	return func(k *AgileKeychain) {
+		k.titleNormalizer = normalizer
+	}
+}
+
+// put a title, or a title being searched for, into the keychain's normal form
+func (k *AgileKeychain) normalizeTitle(title string) string {
+	if k.titleNormalizer == nil {
+		return norm.NFC.String(title)
+	}
+	return k.titleNormalizer(title)
+}
+
+// FindByTitle returns the items titled title, in contents.js order.  Titles
+// are compared in NFC, or after applying the WithTitleNormalizer function.
+func (k *AgileKeychain) FindByTitle(title string) []Item {
+	title = k.normalizeTitle(title)
+
+	var ret []Item
+	for _, entry := range k.contents {
+		if k.normalizeTitle(entry.title) == title {
+			ret = append(ret, entry.item())
+		}
+	}
+	return ret
+}
+
+// GetByTitle returns the items titled title, ignoring case, in contents.js
+// order.  Titles aren't unique, so there may be several; no match is an
+// empty slice, not an error.  As with FindByTitle, titles are normalized
+// first.
+func (k *AgileKeychain) GetByTitle(title string) ([]*Item, error) {
+	title = k.normalizeTitle(title)
+
+	ret := []*Item{}
+	for _, entry := range k.contents {
+		if strings.EqualFold(k.normalizeTitle(entry.title), title) {
+			item := entry.item()
+			ret = append(ret, &item)
+		}
+	}
+	return ret, nil
+}
+
+// SearchOptions narrows a Search
+type SearchOptions struct {
+	// Types, if not empty, restricts the search to items of these types
+	Types []ItemType
+	// Exact matches only titles or sites equal to the query, ignoring case,
+	// rather than those containing it
+	Exact bool
+}
+
+// Search returns the items whose title or site contains query, ignoring case,
+// sorted by title.  Only contents.js is searched, so nothing is decrypted.
+// Titles are normalized as for FindByTitle, and deleted items are never
+// returned.  An empty query with Exact unset
+// matches every item of the given types.
+func (k *AgileKeychain) Search(query string, opts SearchOptions) []Item {
+	query = strings.ToLower(k.normalizeTitle(query))
+	matches := func(s string) bool {
+		s = strings.ToLower(s)
+		if opts.Exact {
+			return s == query
+		}
+		return strings.Contains(s, query)
+	}
+
+	types := make(map[ItemType]bool, len(opts.Types))
+	for _, t := range opts.Types {
+		types[t] = true
+	}
+
+	ret := []Item{}
+	for _, entry := range k.contents {
+		item := entry.item()
+		if entry.entryType == tombstoneType || (len(types) > 0 && !types[item.ItemType()]) {
+			continue
+		}
+		if matches(k.normalizeTitle(entry.title)) || matches(entry.site) {
+			ret = append(ret, item)
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return strings.ToLower(ret[i].Title) < strings.ToLower(ret[j].Title)
+	})
+	return ret
+}
+
+// SearchRegexp returns the items whose title or site matches the regular
+// expression pattern, in the syntax of the regexp package, sorted by title.
+// Deleted items are never returned.  An invalid pattern returns its compile
+// error.
+//
+// With searchFields set, the values of each item's decrypted fields are
+// matched too, as GetField would find them.  That decrypts every item in the
+// keychain on every call, which costs far more than matching contents.js
+// alone; items that fail to decrypt are still matched on title and site, and
+// reported together in an ItemErrors alongside the results.
+func (k *AgileKeychain) SearchRegexp(pattern string, searchFields bool) ([]Item, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []Item{}
+	failures := ItemErrors{}
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		matched := re.MatchString(k.normalizeTitle(entry.title)) || re.MatchString(entry.site)
+		if !matched && searchFields {
+			data, err := k.DecryptItem(entry.id)
+			if err != nil {
+				failures[entry.id] = err
+				continue
+			}
+			for _, value := range fieldValues(data) {
+				if re.MatchString(value) {
+					matched = true
+					break
+				}
+			}
+		}
+
+		if matched {
+			ret = append(ret, entry.item())
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return strings.ToLower(ret[i].Title) < strings.ToLower(ret[j].Title)
+	})
+	if len(failures) > 0 {
+		return ret, failures
+	}
+	return ret, nil
+}
@@ -0,0 +1,175 @@
+package agilekeychain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindByTitle_UnicodeNormalization(t *testing.T) {
+	// the fixture's one item is titled "Café" with a precomposed é (NFC)
+	fixturePath := "../testdata/agilekeychain/unicode/1Password.agilekeychain"
+	nfc := "Caf\u00e9"
+	nfd := "Cafe\u0301"
+
+	keychain := &AgileKeychain{baseDir: fixturePath}
+	err := keychain.loadContents()
+	if err != nil {
+		t.Fatalf("loadContents() error = %v", err)
+	}
+
+	for _, title := range []string{nfc, nfd} {
+		got := keychain.FindByTitle(title)
+		if len(got) != 1 || got[0].ID != "C0FFEE00C0FFEE00C0FFEE00C0FFEE01" {
+			t.Errorf("FindByTitle(%q) = %v, want the one item", title, got)
+		}
+		if got := keychain.Search(strings.ToLower(title), SearchOptions{}); len(got) != 1 {
+			t.Errorf("Search(%q) = %v, want the one item", title, got)
+		}
+		if got, _ := keychain.GetByTitle(title); len(got) != 1 {
+			t.Errorf("GetByTitle(%q) = %v, want the one item", title, got)
+		}
+	}
+
+	// and the other way round, with an NFD title stored and an NFC query
+	keychain.contents[0].title = nfd
+	if got := keychain.FindByTitle(nfc); len(got) != 1 {
+		t.Errorf("FindByTitle(NFC) of NFD title = %v, want the one item", got)
+	}
+	if got := keychain.Search("caf\u00e9", SearchOptions{}); len(got) != 1 {
+		t.Errorf("Search(NFC) of NFD title = %v, want the one item", got)
+	}
+
+	// an overriding normalizer replaces NFC
+	WithTitleNormalizer(func(s string) string { return s })(keychain)
+	if got := keychain.FindByTitle(nfc); len(got) != 0 {
+		t.Errorf("FindByTitle(NFC) of NFD title without normalization = %v, want nothing", got)
+	}
+}
+
+func TestGetByTitle(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		title string
+		want  []string
+	}{
+		{title: "Hulu", want: []string{"13C8E12AC8E54B1F873BAB0824E521BC"}},
+		{title: "hULU", want: []string{"13C8E12AC8E54B1F873BAB0824E521BC"}},
+		{title: "the unofficial apple weblog", want: []string{"D8F79F17D6384808848B213EB4946ECA"}},
+		{title: "Hul", want: []string{}},
+		{title: "Gmail", want: []string{}},
+	}
+	for _, tt := range tests {
+		got, err := keychain.GetByTitle(tt.title)
+		if err != nil {
+			t.Errorf("GetByTitle(%q) error = %v", tt.title, err)
+			continue
+		}
+		if got == nil {
+			t.Errorf("GetByTitle(%q) = nil, want an empty slice", tt.title)
+		}
+		ids := []string{}
+		for _, item := range got {
+			ids = append(ids, item.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("GetByTitle(%q) = %v, want %v", tt.title, ids, tt.want)
+		}
+	}
+
+	// titles aren't unique
+	keychain.contents[3].title = "HULU"
+	if got, _ := keychain.GetByTitle("hulu"); len(got) != 2 {
+		t.Errorf("GetByTitle() with two matching titles = %v, want both", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	titles := func(items []Item) []string {
+		ret := []string{}
+		for _, item := range items {
+			ret = append(ret, item.Title)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		opts  SearchOptions
+		want  []string
+	}{
+		{"Title substring, sorted", "ca", SearchOptions{}, []string{"Bank of America", "CapitalOne MasterCard ***3456"}},
+		{"Site substring", "getdropbox", SearchOptions{}, []string{"Dropbox"}},
+		{"Ignores case", "HULU", SearchOptions{}, []string{"Hulu"}},
+		{"Type filter", "", SearchOptions{Types: []ItemType{ItemTypeCreditCard, ItemTypeIdentity}}, []string{"Business", "CapitalOne MasterCard ***3456", "Chase VISA ***4356", "Personal"}},
+		{"Type filter excludes", "ca", SearchOptions{Types: []ItemType{ItemTypeLogin}}, []string{"Bank of America"}},
+		{"Exact title", "tumblr", SearchOptions{Exact: true}, []string{"Tumblr"}},
+		{"Exact site", "last.fm", SearchOptions{Exact: true}, []string{"Last.fm"}},
+		{"Exact needs the whole title", "tumb", SearchOptions{Exact: true}, []string{}},
+		{"No match", "nothing like this", SearchOptions{}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := titles(keychain.Search(tt.query, tt.opts))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	for _, item := range keychain.Search("", SearchOptions{}) {
+		if item.ItemType() == ItemTypeTombstone {
+			t.Errorf("Search() returned deleted item %s", item.ID)
+		}
+	}
+}
+
+func TestSearchRegexp(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		pattern      string
+		searchFields bool
+		want         []string
+	}{
+		{"Titles, sorted", `^(Skype|Hulu)$`, false, []string{"Hulu", "Skype"}},
+		{"Sites", `\.fm$`, false, []string{"Last.fm"}},
+		{"Fields not searched without the flag", `vet4juf4`, false, []string{}},
+		{"Login field", `vet4juf4`, true, []string{"Dropbox"}},
+		{"Top-level field", `^1PW3-`, true, []string{"1Password"}},
+		{"Titles still match with the flag", `^Hulu$`, true, []string{"Hulu"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := keychain.SearchRegexp(tt.pattern, tt.searchFields)
+			if err != nil {
+				t.Fatalf("SearchRegexp(%q) error = %v", tt.pattern, err)
+			}
+			got := []string{}
+			for _, item := range items {
+				got = append(got, item.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchRegexp(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := keychain.SearchRegexp(`(`, false); err == nil {
+		t.Error("SearchRegexp() of a bad pattern error = nil, want error")
+	}
+}
@@ -0,0 +1,85 @@
+package agilekeychain
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+)
+
+// SelfTest checks that the whole read path works with passphrase: it re-reads
+// contents.js, decrypts and validates the keys, and decrypts and parses one
+// item, the one with the smallest item file so as to be quick.  It returns nil
+// only if every step succeeds, which makes it a one-call health check for CI
+// and monitoring.  A keychain with no items passes once its keys validate.
+func (k *AgileKeychain) SelfTest(passphrase string) error {
+	err := k.Reload()
+	if err != nil {
+		return fmt.Errorf("Failed to read keychain contents: %v", err)
+	}
+
+	err = k.loadEncryptionKeys(passphrase)
+	if err != nil {
+		return fmt.Errorf("Failed to unlock keys: %v", err)
+	}
+
+	smallestID := ""
+	smallestSize := int64(-1)
+	for _, entry := range k.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+
+		size, err := k.itemFileSize(entry.id)
+		if err != nil {
+			return fmt.Errorf("Failed to find item %s: %v", entry.id, err)
+		}
+
+		if smallestSize < 0 || size < smallestSize {
+			smallestID, smallestSize = entry.id, size
+		}
+	}
+
+	if smallestID == "" {
+		return nil
+	}
+
+	_, err = k.DecryptItem(smallestID)
+	if err != nil {
+		return fmt.Errorf("Failed to decrypt item %s: %v", smallestID, err)
+	}
+	return nil
+}
+
+// the size in bytes of the item file for id, without reading it unless it
+// comes from an itemOpener, which is the only way to find out
+func (k *AgileKeychain) itemFileSize(id string) (int64, error) {
+	err := validateItemID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	name := id + ".1password"
+	filePath := path.Join(k.dataDir(), name)
+	if k.sharedRead {
+		data, ok := k.snapshot[filePath]
+		if !ok {
+			return 0, &os.PathError{Op: "stat", Path: filePath, Err: os.ErrNotExist}
+		}
+		return int64(len(data)), nil
+	}
+
+	if k.itemOpener != nil {
+		data, err := k.readStreamedFile(filePath)
+		if err != nil {
+			return 0, err
+		}
+		return int64(len(data)), nil
+	}
+
+	info, err := fs.Stat(k.dirFS(), path.Join(k.vaultDir(), name))
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,54 @@
+package agilekeychain
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	err = keychain.SelfTest("1Password")
+	if err != nil {
+		t.Errorf("SelfTest() error = %v", err)
+	}
+
+	err = keychain.SelfTest("wrong")
+	if err == nil {
+		t.Errorf("SelfTest() with wrong passphrase succeeded")
+	}
+
+	// corrupt the smallest item, which is the one SelfTest decrypts
+	var smallest string
+	var smallestSize int64 = -1
+	for _, entry := range keychain.contents {
+		if entry.entryType == tombstoneType {
+			continue
+		}
+		info, err := os.Stat(path.Join(keychainPath, "data", "default", entry.id+".1password"))
+		if err != nil {
+			t.Fatalf("Failed to stat item: %v", err)
+		}
+		if smallestSize < 0 || info.Size() < smallestSize {
+			smallest, smallestSize = entry.id, info.Size()
+		}
+	}
+
+	itemPath := path.Join(keychainPath, "data", "default", smallest+".1password")
+	err = ioutil.WriteFile(itemPath, []byte(`{"uuid":"`+smallest+`","encrypted":"U2FsdGVkX18AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write item: %v", err)
+	}
+
+	err = keychain.SelfTest("1Password")
+	if err == nil {
+		t.Errorf("SelfTest() with corrupted item %s succeeded", smallest)
+	}
+}
@@ -0,0 +1,135 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// how many times to read a file that changed while it was being read, or
+// that doesn't parse, and how long to wait before the first retry; the wait
+// doubles each time
+const (
+	snapshotReadAttempts = 5
+	snapshotRetryDelay   = 10 * time.Millisecond
+)
+
+// WithSharedRead opens the keychain in shared read mode, for keychains that
+// another process such as the 1Password app may be writing to.  At open time
+// (and on Reload) contents.js, encryptionKeys.js and every item file are read
+// into memory, and from then on the keychain only reads from that snapshot.
+//
+// Each file is read again, after a short backoff, if its size or
+// modification time changes while it is being read, and contents.js and
+// encryptionKeys.js are also read again if they don't parse as JSON.  This
+// makes a torn read unlikely but can't rule it out: a writer that pauses
+// mid-file, or truncates and rewrites a file within the filesystem's
+// timestamp granularity, can still leave a snapshot of an item file that is
+// half old and half new, and a .js file that is still half-written after
+// every retry is kept as read and fails to parse.  The snapshot as a whole
+// is not atomic either.  A writer that touches several files may be caught
+// between them, leaving contents.js listing an item whose file isn't in the
+// snapshot, or an item file newer than its contents.js entry.  Such items fail
+// to load as they would in a keychain that was genuinely inconsistent; a
+// later Reload picks up the writer's finished state.  RawFile also reads from
+// the snapshot.
+func WithSharedRead(sharedRead bool) Option {
+	return func(k *AgileKeychain) {
+		k.sharedRead = sharedRead
+	}
+}
+
+// read every file that the keychain parses into memory
+func (k *AgileKeychain) takeSnapshot() error {
+	dataDir := k.dataDir()
+	files, err := ioutil.ReadDir(dataDir)
+	if err != nil {
+		return err
+	}
+
+	snapshot := make(map[string][]byte, len(files))
+	for _, file := range files {
+		name := file.Name()
+		if !file.Mode().IsRegular() || !(strings.HasSuffix(name, ".js") || strings.HasSuffix(name, ".1password")) {
+			continue
+		}
+
+		filePath := path.Join(dataDir, name)
+		var valid func([]byte) bool
+		if strings.HasSuffix(name, ".js") {
+			valid = func(data []byte) bool {
+				return json.Valid(relaxJSON(data))
+			}
+		}
+		data, err := readFileStable(filePath, valid)
+		if os.IsNotExist(err) {
+			// deleted since the directory was listed
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		snapshot[filePath] = data
+	}
+
+	k.snapshot = snapshot
+	return nil
+}
+
+// read a file, retrying with backoff if its size or modification time
+// changes during the read, which means another process was writing it, or if
+// valid, when given, rejects it.  A file that is stable but never valid is
+// returned as last read, for its parser to report.
+func readFileStable(filePath string, valid func([]byte) bool) ([]byte, error) {
+	var invalid []byte
+	delay := snapshotRetryDelay
+	for attempt := 0; attempt < snapshotReadAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		before, err := os.Stat(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		after, err := os.Stat(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if before.Size() != after.Size() || !before.ModTime().Equal(after.ModTime()) || int64(len(data)) != after.Size() {
+			invalid = nil
+			continue
+		}
+		if valid != nil && !valid(data) {
+			invalid = data
+			continue
+		}
+		return data, nil
+	}
+
+	if invalid != nil {
+		return invalid, nil
+	}
+	return nil, fmt.Errorf("%s kept changing while being read", filePath)
+}
+
+// IsLocked makes a best-effort check of whether another process holds an
+// exclusive advisory lock on the keychain at keychainPath, as a writer in the
+// middle of an update might.  Not every writer takes such a lock, so false
+// doesn't mean the keychain is idle; open it WithSharedRead if it may be
+// written to.  Always false on platforms without flock.
+func IsLocked(keychainPath string) (bool, error) {
+	return fileLocked(path.Join(keychainPath, "data", defaultVault, "contents.js"))
+}
@@ -0,0 +1,130 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAgileKeychain_SharedRead(t *testing.T) {
+	keychainPath := copyFixture(t)
+	dataDir := path.Join(keychainPath, "data", "default")
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password", WithSharedRead(true))
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain in shared read mode: %v", err)
+	}
+
+	want, err := keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C")
+	if err != nil {
+		t.Fatalf("DecryptItem() error = %v", err)
+	}
+
+	// a writer clobbering files after open doesn't affect the snapshot
+	err = ioutil.WriteFile(path.Join(dataDir, "contents.js"), []byte(`[["half-writ`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write contents: %v", err)
+	}
+	err = os.Remove(path.Join(dataDir, "4E36C011EE8348B1B24418218B04018C.1password"))
+	if err != nil {
+		t.Fatalf("Failed to remove item: %v", err)
+	}
+
+	got, err := keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C")
+	if err != nil {
+		t.Fatalf("DecryptItem() after file removed error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecryptItem() from snapshot = %v, want %v", got, want)
+	}
+
+	wantContents, err := ioutil.ReadFile(path.Join(example1Path, "data", "default", "contents.js"))
+	if err != nil {
+		t.Fatalf("Failed to read contents: %v", err)
+	}
+	gotContents, err := keychain.RawFile("contents.js")
+	if err != nil || !bytes.Equal(gotContents, wantContents) {
+		t.Errorf("RawFile(\"contents.js\") = %q, %v, want the snapshot's contents", gotContents, err)
+	}
+
+	// reloading mid-write fails and keeps the old snapshot
+	err = keychain.Reload()
+	if err == nil {
+		t.Fatalf("Reload() of half-written contents.js succeeded")
+	}
+	if keychain.Length() != 19 {
+		t.Errorf("Length() after failed Reload() = %d, want 19", keychain.Length())
+	}
+	_, err = keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C")
+	if err != nil {
+		t.Errorf("DecryptItem() after failed Reload() error = %v", err)
+	}
+
+	// and once the writer is done, Reload sees the new state
+	err = ioutil.WriteFile(path.Join(dataDir, "contents.js"), []byte(`[]`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write contents: %v", err)
+	}
+	err = keychain.Reload()
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if keychain.Length() != 0 {
+		t.Errorf("Length() after Reload() = %d, want 0", keychain.Length())
+	}
+	_, err = keychain.DecryptItem("4E36C011EE8348B1B24418218B04018C")
+	if !os.IsNotExist(err) {
+		t.Errorf("DecryptItem() of removed item after Reload() error = %v, want not exist", err)
+	}
+}
+
+func TestReadFileStable_RetriesUnparseable(t *testing.T) {
+	filePath := path.Join(t.TempDir(), "contents.js")
+	err := ioutil.WriteFile(filePath, []byte(`[["half-writ`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write contents: %v", err)
+	}
+
+	// the writer finishes while the reader is backing off
+	done := make(chan error)
+	go func() {
+		time.Sleep(snapshotRetryDelay / 2)
+		done <- ioutil.WriteFile(filePath, []byte(`[]`), 0644)
+	}()
+
+	valid := func(data []byte) bool {
+		return json.Valid(data)
+	}
+	data, err := readFileStable(filePath, valid)
+	if err := <-done; err != nil {
+		t.Fatalf("Failed to write contents: %v", err)
+	}
+	if err != nil || string(data) != "[]" {
+		t.Errorf("readFileStable() = %q, %v, want the finished file", data, err)
+	}
+
+	// a file that never parses is returned as read
+	err = ioutil.WriteFile(filePath, []byte(`[[`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write contents: %v", err)
+	}
+	data, err = readFileStable(filePath, valid)
+	if err != nil || string(data) != "[[" {
+		t.Errorf("readFileStable() of a broken file = %q, %v, want it as read", data, err)
+	}
+}
+
+func TestIsLocked(t *testing.T) {
+	locked, err := IsLocked(example1Path)
+	if err != nil {
+		t.Fatalf("IsLocked() error = %v", err)
+	}
+	if locked {
+		t.Errorf("IsLocked() = true for an idle keychain")
+	}
+}
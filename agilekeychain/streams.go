@@ -0,0 +1,92 @@
+package agilekeychain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+// NewAgileKeychainFromReaders creates an AgileKeychain that isn't on disk,
+// such as one served over HTTP.  contents and keys supply contents.js and
+// encryptionKeys.js, which are read in full before this returns.  Item files
+// are fetched lazily: itemOpener is called with an item's id each time the
+// item's .1password file is needed, and the ReadCloser it returns is read to
+// the end and closed.  itemOpener should return an error wrapping
+// os.ErrNotExist for items it doesn't have.
+//
+// There is no keychain directory, so methods that look at other files in
+// one, such as Vaults, aren't meaningful, and those that need to list or
+// stat files, such as OrphanedItemFiles and DumpIndex, fail with an error
+// wrapping errors.ErrUnsupported.  The keychain is read-only: RelevelItem
+// fails with ErrReadOnly.  Reload re-parses the contents.js already read
+// rather than fetching it again.  WithSharedRead, WithIndex and WithBaseDir
+// have no effect.
+func NewAgileKeychainFromReaders(contents io.Reader, keys io.Reader, itemOpener func(id string) (io.ReadCloser, error), passphrase string, opts ...Option) (*AgileKeychain, error) {
+	if itemOpener == nil {
+		return nil, errors.New("NewAgileKeychainFromReaders needs an itemOpener")
+	}
+
+	ret := &AgileKeychain{}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	ret.sharedRead = false
+	ret.index = nil
+	ret.itemOpener = itemOpener
+	ret.readOnly = true
+
+	contentsData, err := ioutil.ReadAll(contents)
+	if err != nil {
+		return nil, err
+	}
+	keysData, err := ioutil.ReadAll(keys)
+	if err != nil {
+		return nil, err
+	}
+	ret.snapshot = map[string][]byte{
+		path.Join(ret.dataDir(), "contents.js"):       contentsData,
+		path.Join(ret.dataDir(), "encryptionKeys.js"): keysData,
+	}
+
+	err = ret.loadContents()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ret.unlock(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// the error for methods that need a keychain directory to list or stat
+func errNoDirectory(what string) error {
+	return fmt.Errorf("Failed to %s (%w): a keychain read from readers has no directory", what, errors.ErrUnsupported)
+}
+
+// read a file of a keychain made by NewAgileKeychainFromReaders: contents.js
+// and encryptionKeys.js from memory, and item files from itemOpener
+func (k *AgileKeychain) readStreamedFile(filePath string) ([]byte, error) {
+	if data, ok := k.snapshot[filePath]; ok {
+		return data, nil
+	}
+
+	dir, name := path.Split(filePath)
+	if path.Clean(dir) != k.dataDir() || !strings.HasSuffix(name, ".1password") {
+		return nil, &os.PathError{Op: "open", Path: filePath, Err: os.ErrNotExist}
+	}
+
+	r, err := k.itemOpener(strings.TrimSuffix(name, ".1password"))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
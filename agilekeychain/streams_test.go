@@ -0,0 +1,134 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestNewAgileKeychainFromReaders(t *testing.T) {
+	dataDir := path.Join(example1Path, "data", "default")
+	contents, err := ioutil.ReadFile(path.Join(dataDir, "contents.js"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	keys, err := ioutil.ReadFile(path.Join(dataDir, "encryptionKeys.js"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	var opened []string
+	opener := func(id string) (io.ReadCloser, error) {
+		opened = append(opened, id)
+		return os.Open(path.Join(dataDir, id+".1password"))
+	}
+
+	keychain, err := NewAgileKeychainFromReaders(bytes.NewReader(contents), bytes.NewReader(keys), opener, "1Password")
+	if err != nil {
+		t.Fatalf("NewAgileKeychainFromReaders() error = %v", err)
+	}
+	if len(opened) != 0 {
+		t.Errorf("opened %v before any item was needed", opened)
+	}
+
+	onDisk, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	if got, want := keychain.List(), onDisk.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %+v, want %+v", got, want)
+	}
+
+	const id = "5ADFF73C09004C448D45565BC4750DE2"
+	got, err := keychain.DecryptItem(id)
+	if err != nil {
+		t.Fatalf("DecryptItem() error = %v", err)
+	}
+	want, err := onDisk.DecryptItem(id)
+	if err != nil {
+		t.Fatalf("DecryptItem() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecryptItem() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(opened, []string{id}) {
+		t.Errorf("opened %v, want just %s", opened, id)
+	}
+
+	if err := keychain.Reload(); err != nil {
+		t.Errorf("Reload() error = %v", err)
+	}
+}
+
+func TestNewAgileKeychainFromReaders_NoDirectory(t *testing.T) {
+	dataDir := path.Join(example1Path, "data", "default")
+	contents, err := ioutil.ReadFile(path.Join(dataDir, "contents.js"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	keys, err := ioutil.ReadFile(path.Join(dataDir, "encryptionKeys.js"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	opener := func(id string) (io.ReadCloser, error) {
+		return os.Open(path.Join(dataDir, id+".1password"))
+	}
+
+	keychain, err := NewAgileKeychainFromReaders(bytes.NewReader(contents), bytes.NewReader(keys), opener, "1Password")
+	if err != nil {
+		t.Fatalf("NewAgileKeychainFromReaders() error = %v", err)
+	}
+
+	// item files come from the opener
+	if err := keychain.SelfTest("1Password"); err != nil {
+		t.Errorf("SelfTest() error = %v", err)
+	}
+	data, err := keychain.RawFile("contents.js")
+	if err != nil || !bytes.Equal(data, contents) {
+		t.Errorf("RawFile(contents.js) = %d bytes, %v, want the contents read", len(data), err)
+	}
+
+	// nothing reads the current directory instead
+	if _, err := keychain.OrphanedItemFiles(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("OrphanedItemFiles() error = %v, want errors.ErrUnsupported", err)
+	}
+	if err := keychain.DumpIndex(ioutil.Discard); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("DumpIndex() error = %v, want errors.ErrUnsupported", err)
+	}
+	if err := keychain.RelevelItem("5ADFF73C09004C448D45565BC4750DE2", "SL3"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("RelevelItem() error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestNewAgileKeychainFromReaders_Errors(t *testing.T) {
+	dataDir := path.Join(example1Path, "data", "default")
+	keys, err := ioutil.ReadFile(path.Join(dataDir, "encryptionKeys.js"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	missing := func(id string) (io.ReadCloser, error) {
+		return nil, os.ErrNotExist
+	}
+
+	keychain, err := NewAgileKeychainFromReaders(bytes.NewReader([]byte(`[["13C8E12AC8E54B1F873BAB0824E521BC","webforms.WebForm","Hulu","hulu.com",1362350139,"",0,"N"]]`)), bytes.NewReader(keys), missing, "1Password")
+	if err != nil {
+		t.Fatalf("NewAgileKeychainFromReaders() error = %v", err)
+	}
+	if _, err := keychain.DecryptItem("13C8E12AC8E54B1F873BAB0824E521BC"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("DecryptItem() of a missing item error = %v, want os.ErrNotExist", err)
+	}
+
+	_, err = NewAgileKeychainFromReaders(bytes.NewReader([]byte(`[]`)), bytes.NewReader(keys), missing, "wrong")
+	if !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("NewAgileKeychainFromReaders(wrong passphrase) error = %v, want ErrWrongPassphrase", err)
+	}
+	_, err = NewAgileKeychainFromReaders(bytes.NewReader([]byte(`[]`)), bytes.NewReader(keys), nil, "1Password")
+	if err == nil {
+		t.Error("NewAgileKeychainFromReaders(nil itemOpener) error = nil, want error")
+	}
+}
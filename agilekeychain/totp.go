@@ -0,0 +1,88 @@
+package agilekeychain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/emerose/passync/internal/qrcode"
+)
+
+// the otpauth:// URI for the TOTP seed in a decrypted item.  1Password keeps
+// it in a section field named TOTP_<uuid>, either as a full otpauth:// URI or
+// as a bare base32 secret, which gets a URI labelled with the item's title.
+// Any other field holding an otpauth:// URI counts too.
+func totpURI(data map[string]interface{}, title string) (string, bool) {
+	sections, _ := data["sections"].([]interface{})
+	for _, rawSection := range sections {
+		section, ok := rawSection.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sectionFields, _ := section["fields"].([]interface{})
+		for _, rawField := range sectionFields {
+			field, ok := rawField.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := field["n"].(string)
+			value, _ := field["v"].(string)
+			if !strings.HasPrefix(name, "TOTP_") || value == "" {
+				continue
+			}
+
+			if strings.HasPrefix(value, "otpauth://") {
+				return value, true
+			}
+			secret := strings.ToUpper(strings.Join(strings.Fields(value), ""))
+			return "otpauth://totp/" + url.PathEscape(title) + "?secret=" + url.QueryEscape(secret), true
+		}
+	}
+
+	for _, value := range fieldValues(data) {
+		if strings.HasPrefix(value, "otpauth://") {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// TOTPQRCode returns a PNG of a QR code for the item's TOTP seed, as an
+// otpauth:// URI, for enrolling it in another authenticator app.  Returns
+// ErrNoTOTP if the item has no TOTP seed.  The QR encoder holds at most 213
+// bytes, which a URI with a long label or issuer can exceed; such items
+// return an error rather than a code.
+func (k *AgileKeychain) TOTPQRCode(id string) ([]byte, error) {
+	entry, err := k.GetItem(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var uri string
+	err = k.withDecryptedItem(id, func(item *itemFile, plaintext []byte) error {
+		var data map[string]interface{}
+		err := json.Unmarshal(plaintext, &data)
+		if err != nil {
+			return fmt.Errorf("Failed to parse decrypted item %s: %v", id, err)
+		}
+
+		var ok bool
+		uri, ok = totpURI(data, entry.Title)
+		if !ok {
+			return fmt.Errorf("%w: item %s", ErrNoTOTP, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	qr, err := qrcode.Encode([]byte(uri))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to encode TOTP URI for item %s: %v", id, err)
+	}
+	return qr.PNG(8)
+}
@@ -0,0 +1,115 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/emerose/passync/internal/qrcode"
+)
+
+func TestTOTPURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+		ok      bool
+	}{
+		{
+			name:    "Section field with a URI",
+			payload: `{"sections":[{"fields":[{"n":"TOTP_1","k":"concealed","t":"one-time password","v":"otpauth://totp/Hulu?secret=JBSWY3DPEHPK3PXP"}]}]}`,
+			want:    "otpauth://totp/Hulu?secret=JBSWY3DPEHPK3PXP",
+			ok:      true,
+		},
+		{
+			name:    "Section field with a bare secret",
+			payload: `{"sections":[{"fields":[{"n":"TOTP_1","k":"concealed","v":"jbsw y3dp ehpk 3pxp"}]}]}`,
+			want:    "otpauth://totp/My%20Site?secret=JBSWY3DPEHPK3PXP",
+			ok:      true,
+		},
+		{
+			name:    "URI in some other field",
+			payload: `{"notesPlain":"otpauth://totp/x?secret=ABC"}`,
+			want:    "otpauth://totp/x?secret=ABC",
+			ok:      true,
+		},
+		{
+			name:    "No TOTP",
+			payload: `{"fields":[{"name":"password","value":"hunter2"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(tt.payload), &data); err != nil {
+			t.Fatalf("Failed to parse JSON: %v", err)
+		}
+		got, ok := totpURI(data, "My Site")
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: totpURI() = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestTOTPQRCode(t *testing.T) {
+	keychainPath := copyFixture(t)
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	const generatedID = "9E4E4A7ED9E4E4A7ED9E4E4A7ED9E402"
+	const uri = "otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"
+	encrypted, err := encryptItemPayload(keychain.encKeys.sl5.key,
+		[]byte(`{"sections":[{"fields":[{"n":"TOTP_1","k":"concealed","t":"one-time password","v":"`+uri+`"}]}]}`))
+	if err != nil {
+		t.Fatalf("encryptItemPayload() error = %v", err)
+	}
+	itemJSON, err := json.Marshal(map[string]interface{}{
+		"uuid":         generatedID,
+		"title":        "Example",
+		"typeName":     "webforms.WebForm",
+		"keyID":        keychain.encKeys.sl5.id,
+		"encrypted":    encrypted,
+		"openContents": map[string]interface{}{"securityLevel": "SL5"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+	err = ioutil.WriteFile(path.Join(keychainPath, "data", "default", generatedID+".1password"), itemJSON, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	appendContentsEntries(t, keychainPath, `["`+generatedID+`","webforms.WebForm","Example","example.com",1362350139,"",0,"N"]`)
+	if err := keychain.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	got, err := keychain.TOTPQRCode(generatedID)
+	if err != nil {
+		t.Fatalf("TOTPQRCode() error = %v", err)
+	}
+	qr, err := qrcode.Encode([]byte(uri))
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want, err := qr.PNG(8)
+	if err != nil {
+		t.Fatalf("PNG() error = %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("TOTPQRCode() isn't a QR code of the item's otpauth URI")
+	}
+
+	_, err = keychain.TOTPQRCode("13C8E12AC8E54B1F873BAB0824E521BC")
+	if !errors.Is(err, ErrNoTOTP) {
+		t.Errorf("TOTPQRCode() of an item without TOTP error = %v, want ErrNoTOTP", err)
+	}
+
+	_, err = keychain.TOTPQRCode("nonexistent")
+	if !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("TOTPQRCode() of a missing item error = %v, want ErrItemNotFound", err)
+	}
+}
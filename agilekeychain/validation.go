@@ -0,0 +1,117 @@
+package agilekeychain
+
+import (
+	"crypto/aes"
+	"crypto/sha1"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// ValidationKDF is the key derivation function used to decrypt the
+// validation blob stored alongside each key in encryptionKeys.js
+type ValidationKDF int
+
+const (
+	// ValidationKDFAuto tries ValidationKDFOpenSSL, then ValidationKDFPBKDF2
+	ValidationKDFAuto ValidationKDF = iota
+	// ValidationKDFOpenSSL uses OpenSSL's MD5-based EVP_BytesToKey, which is
+	// what 1Password itself writes
+	ValidationKDFOpenSSL
+	// ValidationKDFPBKDF2 uses PBKDF2-SHA1 with the key's iteration count, as
+	// some third-party tools do
+	ValidationKDFPBKDF2
+)
+
+// WithValidationKDF sets the key derivation function used when validating
+// keys.  The default is ValidationKDFAuto, which costs an extra PBKDF2 run
+// per key when validation with the OpenSSL KDF fails, e.g. on a wrong
+// passphrase.
+func WithValidationKDF(kdf ValidationKDF) Option {
+	return func(k *AgileKeychain) {
+		k.validationKDF = kdf
+	}
+}
+
+// check that the validation blob decrypts, using keyBytes as the password, to
+// keyBytes itself
+func validateKey(keyBytes []byte, validationBytes []byte, iterations int, kdf ValidationKDF) error {
+	if kdf == ValidationKDFAuto {
+		err := validateKey(keyBytes, validationBytes, iterations, ValidationKDFOpenSSL)
+		if err == nil {
+			return nil
+		}
+		return validateKey(keyBytes, validationBytes, iterations, ValidationKDFPBKDF2)
+	}
+
+	salt, blob, err := extractSalt(validationBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrKeyValidationFailed, err)
+	}
+
+	var kek, iv []byte
+	switch kdf {
+	case ValidationKDFOpenSSL:
+		kek, iv = deriveOpensslKey(keyBytes, salt, 16, aes.BlockSize)
+	case ValidationKDFPBKDF2:
+		derivedKey := pbkdf2.Key(keyBytes, salt, iterations, 32, sha1.New)
+		kek, iv = derivedKey[0:16], derivedKey[16:32]
+	default:
+		return errors.New("Unknown validation KDF")
+	}
+
+	validationResult, err := cbcDecrypt(blob, kek, iv)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrKeyValidationFailed, err)
+	}
+
+	// constant time, so as not to leak how much of the key matched
+	if subtle.ConstantTimeCompare(keyBytes, validationResult) != 1 {
+		return ErrKeyValidationFailed
+	}
+	return nil
+}
+
+// ValidationStatus reports, for each security level, whether its key
+// decrypted and validated the last time a passphrase was tried, whether at
+// open time or by a method taking a passphrase.  Levels whose key validated
+// map to nil; the others map to the reason they didn't, so that when
+// unlocking fails it's clear which key is at fault.
+func (k *AgileKeychain) ValidationStatus() map[string]error {
+	ret := make(map[string]error, len(k.validationStatus))
+	for level, err := range k.validationStatus {
+		ret[level] = err
+	}
+	return ret
+}
+
+// ValidationBlob returns the base64-decoded validation data stored with the
+// key for the given security level ("SL3" or "SL5"), for tools that check
+// keys themselves.  It is the key encrypted with itself in OpenSSL's Salted__
+// format, so isn't secret.  Nothing is decrypted.
+func (k *AgileKeychain) ValidationBlob(level string) ([]byte, error) {
+	raw, err := k.readRawEncryptionKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	var id string
+	switch level {
+	case "SL3":
+		id = raw.SL3
+	case "SL5":
+		id = raw.SL5
+	default:
+		return nil, fmt.Errorf("Unknown security level %s", level)
+	}
+
+	for _, rawKey := range raw.List {
+		if rawKey.Identifier == id {
+			return decodeBase64(rawKey.Validation)
+		}
+	}
+
+	return nil, fmt.Errorf("Couldn't find %s key with id %s", level, id)
+}
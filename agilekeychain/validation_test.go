@@ -0,0 +1,179 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+func TestNewAgileKeychain_ValidationKDF(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		kdf     ValidationKDF
+		wantErr bool
+	}{
+		{"OpenSSL fixture, auto", "openssl", ValidationKDFAuto, false},
+		{"OpenSSL fixture, OpenSSL", "openssl", ValidationKDFOpenSSL, false},
+		{"OpenSSL fixture, PBKDF2", "openssl", ValidationKDFPBKDF2, true},
+		{"PBKDF2 fixture, auto", "pbkdf2", ValidationKDFAuto, false},
+		{"PBKDF2 fixture, OpenSSL", "pbkdf2", ValidationKDFOpenSSL, true},
+		{"PBKDF2 fixture, PBKDF2", "pbkdf2", ValidationKDFPBKDF2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixturePath := "../testdata/agilekeychain/validationkdf/" + tt.fixture + "/1Password.agilekeychain"
+
+			keychain, err := NewAgileKeychain(fixturePath, "1Password", WithValidationKDF(tt.kdf))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAgileKeychain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			fields, err := keychain.GetLoginFields("5A1DA7E05A1DA7E05A1DA7E05A1DA701")
+			if err != nil {
+				t.Fatalf("GetLoginFields() error = %v", err)
+			}
+			if len(fields) != 2 || fields[1].Value != "hunter2" {
+				t.Errorf("GetLoginFields() = %+v", fields)
+			}
+		})
+	}
+}
+
+func TestCanOpen_PBKDF2Validation(t *testing.T) {
+	ok, err := CanOpen("../testdata/agilekeychain/validationkdf/pbkdf2/1Password.agilekeychain", "1Password")
+	if err != nil {
+		t.Fatalf("CanOpen() error = %v", err)
+	}
+	if !ok {
+		t.Errorf("CanOpen() = false, want true")
+	}
+}
+
+func TestValidationStatus(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	status := keychain.ValidationStatus()
+	if len(status) != 2 || status["SL3"] != nil || status["SL5"] != nil {
+		t.Errorf("ValidationStatus() after open = %v, want both levels nil", status)
+	}
+
+	err = keychain.loadEncryptionKeys("wrong")
+	if err == nil {
+		t.Fatalf("loadEncryptionKeys() with wrong passphrase succeeded")
+	}
+
+	status = keychain.ValidationStatus()
+	if status["SL3"] == nil || status["SL5"] == nil {
+		t.Errorf("ValidationStatus() after wrong passphrase = %v, want both levels failed", status)
+	}
+}
+
+func TestValidationStatus_OneBadKey(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	// swap in the SL5 key's validation blob for the SL3 key's
+	raw, err := keychain.readRawEncryptionKeys()
+	if err != nil {
+		t.Fatalf("readRawEncryptionKeys() error = %v", err)
+	}
+	var sl5Validation string
+	for _, rawKey := range raw.List {
+		if rawKey.Identifier == raw.SL5 {
+			sl5Validation = rawKey.Validation
+		}
+	}
+	for ix := range raw.List {
+		if raw.List[ix].Identifier == raw.SL3 {
+			raw.List[ix].Validation = sl5Validation
+		}
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("Failed to marshal keys: %v", err)
+	}
+	err = ioutil.WriteFile(path.Join(keychainPath, "data", "default", "encryptionKeys.js"), data, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write keys: %v", err)
+	}
+
+	err = keychain.loadEncryptionKeys("1Password")
+	if err == nil {
+		t.Fatalf("loadEncryptionKeys() with a bad SL3 key succeeded")
+	}
+
+	status := keychain.ValidationStatus()
+	if status["SL3"] == nil || status["SL5"] != nil {
+		t.Errorf("ValidationStatus() = %v, want only SL3 failed", status)
+	}
+}
+
+func TestValidateKey_OneByteMismatch(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 1024)
+
+	validationFor := func(plaintext []byte) []byte {
+		encoded, err := encryptItemPayload(key, plaintext)
+		if err != nil {
+			t.Fatalf("encryptItemPayload() error = %v", err)
+		}
+		blob, err := decodeBase64(encoded)
+		if err != nil {
+			t.Fatalf("decodeBase64() error = %v", err)
+		}
+		return blob
+	}
+
+	if err := validateKey(key, validationFor(key), 1000, ValidationKDFOpenSSL); err != nil {
+		t.Fatalf("validateKey() with a matching blob error = %v", err)
+	}
+
+	for _, ix := range []int{0, 511, 1023} {
+		mismatched := append([]byte(nil), key...)
+		mismatched[ix] ^= 0x01
+		if err := validateKey(key, validationFor(mismatched), 1000, ValidationKDFOpenSSL); err == nil {
+			t.Errorf("validateKey() accepted a blob differing at byte %d", ix)
+		}
+	}
+}
+
+func TestValidationBlob(t *testing.T) {
+	keychain, err := NewAgileKeychain(example1Path, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	keys, err := keychain.keys()
+	if err != nil {
+		t.Fatalf("keys() error = %v", err)
+	}
+
+	for _, key := range []encryptionKey{keys.sl3, keys.sl5} {
+		blob, err := keychain.ValidationBlob(key.level.String())
+		if err != nil {
+			t.Fatalf("ValidationBlob(%s) error = %v", key.level, err)
+		}
+		if !bytes.HasPrefix(blob, []byte("Salted__")) {
+			t.Errorf("ValidationBlob(%s) = %q, want Salted__ prefix", key.level, blob)
+		}
+		if err := validateKey(key.key, blob, key.iterations, ValidationKDFOpenSSL); err != nil {
+			t.Errorf("validateKey(ValidationBlob(%s)) error = %v", key.level, err)
+		}
+	}
+
+	if _, err := keychain.ValidationBlob("SL4"); err == nil {
+		t.Error("ValidationBlob(SL4) error = nil, want error")
+	}
+}
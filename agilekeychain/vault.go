@@ -0,0 +1,65 @@
+package agilekeychain
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// the vault 1Password keeps items in, and the only one most keychains have
+const defaultVault = "default"
+
+// WithVault opens the vault with the given name, one of the directories under
+// the keychain's data directory as listed by Vaults, rather than "default".
+func WithVault(name string) Option {
+	return func(k *AgileKeychain) {
+		k.vault = name
+	}
+}
+
+// the directory holding the files of the vault the keychain was opened with
+func (k *AgileKeychain) dataDir() string {
+	return path.Join(k.baseDir, k.vaultDir())
+}
+
+// dataDir relative to the keychain directory, for use with dirFS
+func (k *AgileKeychain) vaultDir() string {
+	vault := k.vault
+	if vault == "" {
+		vault = defaultVault
+	}
+	return path.Join("data", vault)
+}
+
+// vault names end up in file paths, so make sure they can't point outside
+// the data directory
+func validateVaultName(name string) error {
+	if name == "" || strings.ContainsAny(name, "/\\\x00") || name == "." || name == ".." {
+		return fmt.Errorf("Invalid vault name %q", name)
+	}
+	return nil
+}
+
+// Vaults lists, sorted, the names of the vaults in the keychain: the
+// directories under its data directory that have a contents.js.  Any of them
+// can be opened WithVault.  Empty if the data directory can't be read.
+func (k *AgileKeychain) Vaults() []string {
+	fsys := k.dirFS()
+	entries, err := fs.ReadDir(fsys, "data")
+	if err != nil {
+		return nil
+	}
+
+	var ret []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		fileinfo, err := fs.Stat(fsys, path.Join("data", entry.Name(), "contents.js"))
+		if err == nil && fileinfo.Mode().IsRegular() {
+			ret = append(ret, entry.Name())
+		}
+	}
+	return ret
+}
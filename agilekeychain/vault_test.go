@@ -0,0 +1,54 @@
+package agilekeychain
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestVaults(t *testing.T) {
+	keychainPath := copyFixture(t)
+	dataDir := path.Join(keychainPath, "data")
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+	if got := keychain.Vaults(); !reflect.DeepEqual(got, []string{"default"}) {
+		t.Errorf("Vaults() = %q, want [default]", got)
+	}
+
+	err = os.Rename(path.Join(dataDir, "default"), path.Join(dataDir, "personal"))
+	if err != nil {
+		t.Fatalf("Failed to rename vault: %v", err)
+	}
+	// not a vault, having no contents.js
+	err = os.Mkdir(path.Join(dataDir, "junk"), 0700)
+	if err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	if _, err := NewAgileKeychain(keychainPath, "1Password"); err == nil {
+		t.Error("NewAgileKeychain() without a default vault error = nil, want error")
+	}
+
+	keychain, err = NewAgileKeychain(keychainPath, "1Password", WithVault("personal"))
+	if err != nil {
+		t.Fatalf("NewAgileKeychain(WithVault) error = %v", err)
+	}
+	if got := keychain.Vaults(); !reflect.DeepEqual(got, []string{"personal"}) {
+		t.Errorf("Vaults() = %q, want [personal]", got)
+	}
+	if _, err := keychain.GetLoginFields("5ADFF73C09004C448D45565BC4750DE2"); err != nil {
+		t.Errorf("GetLoginFields() error = %v", err)
+	}
+}
+
+func TestWithVault_InvalidName(t *testing.T) {
+	for _, name := range []string{"..", ".", "../default", "a/b"} {
+		if _, err := NewAgileKeychain(example1Path, "1Password", WithVault(name)); err == nil {
+			t.Errorf("NewAgileKeychain(WithVault(%q)) error = nil, want error", name)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package agilekeychain
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+)
+
+// encrypt an item payload with key, producing the same base64 OpenSSL salted
+// format that decodeEncryptedPayload reads, NUL terminator included
+func encryptItemPayload(key []byte, plaintext []byte) (string, error) {
+	salt := make([]byte, 8)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	itemKey, iv := deriveOpensslKey(key, salt, 16, aes.BlockSize)
+	blob, err := cbcEncrypt(plaintext, itemKey, iv)
+	if err != nil {
+		return "", err
+	}
+
+	salted := append(append([]byte("Salted__"), salt...), blob...)
+	return base64.StdEncoding.EncodeToString(salted) + "\u0000", nil
+}
+
+// write data to filePath by writing a temporary file in the same directory
+// and renaming it into place, so readers never see a partial file
+func writeFileAtomic(filePath string, data []byte) error {
+	tmp, err := ioutil.TempFile(path.Dir(filePath), "."+path.Base(filePath)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filePath)
+}
+
+// look up the loaded key for a security level name
+func (k *AgileKeychain) keyForLevel(level string) (encryptionKey, error) {
+	encKeys, err := k.keys()
+	if err != nil {
+		return encryptionKey{}, err
+	}
+
+	var key encryptionKey
+	switch level {
+	case "SL3":
+		key = encKeys.sl3
+	case "SL5":
+		key = encKeys.sl5
+	default:
+		return key, fmt.Errorf("Unknown security level %s", level)
+	}
+
+	if key.key == nil {
+		return key, fmt.Errorf("No %s key loaded", level)
+	}
+	return key, nil
+}
+
+// RelevelItem re-encrypts the item with the given id under the key for
+// newLevel ("SL3" or "SL5"), updating its key id and declared security
+// level.  The new ciphertext is decrypted again and compared with the
+// original before anything is written, and the item file is replaced
+// atomically.  contents.js doesn't record security levels, so it is left
+// alone.  The change is recorded in the WithChangelog log, if any.
+func (k *AgileKeychain) RelevelItem(id string, newLevel string) error {
+	if k.readOnly {
+		return fmt.Errorf("Failed to relevel item %s: %w", id, ErrReadOnly)
+	}
+
+	_, err := k.GetItem(id)
+	if err != nil {
+		return err
+	}
+
+	newKey, err := k.keyForLevel(newLevel)
+	if err != nil {
+		return err
+	}
+
+	item, err := k.loadItemFile(id)
+	if err != nil {
+		return err
+	}
+
+	oldKey, err := k.keyForItem(item)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := k.decryptItemFile(item)
+	if err != nil {
+		return err
+	}
+
+	k.keyUse.RLock()
+	encrypted, err := encryptItemPayload(newKey.key, plaintext)
+	k.keyUse.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	// make sure the new ciphertext decrypts back to what we started with
+	releveled := *item
+	releveled.KeyID = newKey.id
+	releveled.Encrypted = encrypted
+	releveled.Cipher = ""
+	roundTrip, err := k.decryptItemFile(&releveled)
+	if err != nil {
+		return fmt.Errorf("Failed to verify releveled item %s: %v", id, err)
+	}
+	if !bytes.Equal(roundTrip, plaintext) {
+		return fmt.Errorf("Releveled item %s doesn't decrypt to the original", id)
+	}
+
+	// rewrite the file from its raw JSON so that fields we don't model are
+	// preserved
+	raw, err := k.loadRawItemFile(id)
+	if err != nil {
+		return err
+	}
+
+	openContents, ok := raw["openContents"].(map[string]interface{})
+	if !ok {
+		openContents = make(map[string]interface{})
+		raw["openContents"] = openContents
+	}
+	openContents["securityLevel"] = newLevel
+	raw["keyID"] = newKey.id
+	raw["encrypted"] = encrypted
+	// encryptItemPayload only does CBC
+	delete(raw, "cipher")
+
+	out, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(path.Join(k.dataDir(), id+".1password"), out)
+	if err != nil {
+		return err
+	}
+
+	return k.logChange("relevel", id, oldKey.level.String()+" to "+newLevel)
+}
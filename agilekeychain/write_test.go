@@ -0,0 +1,70 @@
+package agilekeychain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRelevelItem(t *testing.T) {
+	keychainPath := copyFixture(t)
+
+	keychain, err := NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error creating agilekeychain from fixture: %v", err)
+	}
+
+	// Tumblr starts out at SL5
+	id := "5ADFF73C09004C448D45565BC4750DE2"
+	want, err := keychain.DecryptItem(id)
+	if err != nil {
+		t.Fatalf("DecryptItem() error = %v", err)
+	}
+
+	err = keychain.RelevelItem(id, "SL3")
+	if err != nil {
+		t.Fatalf("RelevelItem() error = %v", err)
+	}
+
+	// reopen so nothing is served from memory
+	keychain, err = NewAgileKeychain(keychainPath, "1Password")
+	if err != nil {
+		t.Fatalf("Error reopening agilekeychain: %v", err)
+	}
+
+	level, err := keychain.ItemSecurityLevel(id)
+	if err != nil || level != "SL3" {
+		t.Errorf("ItemSecurityLevel() after relevel = %s, %v, want SL3", level, err)
+	}
+
+	got, err := keychain.DecryptItem(id)
+	if err != nil {
+		t.Fatalf("DecryptItem() after relevel error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Releveled item decrypts to %v, want %v", got, want)
+	}
+
+	item, err := keychain.loadItemFile(id)
+	if err != nil {
+		t.Fatalf("loadItemFile() error = %v", err)
+	}
+	if item.OpenContents.SecurityLevel != "SL3" || item.Location != "http://www.tumblr.com/login" {
+		t.Errorf("Releveled item file has wrong metadata: %+v", item)
+	}
+
+	err = keychain.RelevelItem(id, "SL4")
+	if err == nil {
+		t.Errorf("RelevelItem() to unknown level did not fail")
+	}
+
+	err = keychain.RelevelItem("00000000000000000000000000000000", "SL5")
+	if err == nil {
+		t.Errorf("RelevelItem() of unknown item did not fail")
+	}
+
+	keychain.encKeys.sl5 = encryptionKey{}
+	err = keychain.RelevelItem(id, "SL5")
+	if err == nil {
+		t.Errorf("RelevelItem() to a level without a loaded key did not fail")
+	}
+}
@@ -1,5 +1,9 @@
 module github.com/emerose/passync
 
-go 1.14
+go 1.23.0
 
-require golang.org/x/crypto v0.0.0-20200728195943-123391ffb6de
+require (
+	golang.org/x/crypto v0.36.0
+	golang.org/x/net v0.38.0
+	golang.org/x/text v0.23.0
+)
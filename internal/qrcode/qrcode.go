@@ -0,0 +1,504 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// A minimal QR code encoder, enough to turn an otpauth:// URI into a code an
+// authenticator app can scan: byte mode only, error correction level M, and
+// versions 1 to 10, which hold up to 213 bytes.  See ISO/IEC 18004.
+
+// the block structure of a version at error correction level M: how many
+// error correction codewords each block gets, and the number and data
+// codeword counts of the blocks in each of the (up to) two groups
+type versionBlocks struct {
+	ecPerBlock  int
+	groupBlocks [2]int
+	groupData   [2]int
+}
+
+var versionsM = []versionBlocks{
+	1:  {10, [2]int{1, 0}, [2]int{16, 0}},
+	2:  {16, [2]int{1, 0}, [2]int{28, 0}},
+	3:  {26, [2]int{1, 0}, [2]int{44, 0}},
+	4:  {18, [2]int{2, 0}, [2]int{32, 0}},
+	5:  {24, [2]int{2, 0}, [2]int{43, 0}},
+	6:  {16, [2]int{4, 0}, [2]int{27, 0}},
+	7:  {18, [2]int{4, 0}, [2]int{31, 0}},
+	8:  {22, [2]int{2, 2}, [2]int{38, 39}},
+	9:  {22, [2]int{3, 2}, [2]int{36, 37}},
+	10: {26, [2]int{4, 1}, [2]int{43, 44}},
+}
+
+// the row and column coordinates of the alignment patterns in each version
+var alignmentPositions = [][]int{
+	1:  {},
+	2:  {6, 18},
+	3:  {6, 22},
+	4:  {6, 26},
+	5:  {6, 30},
+	6:  {6, 34},
+	7:  {6, 22, 38},
+	8:  {6, 24, 42},
+	9:  {6, 26, 46},
+	10: {6, 28, 50},
+}
+
+func (b versionBlocks) dataCodewords() int {
+	return b.groupBlocks[0]*b.groupData[0] + b.groupBlocks[1]*b.groupData[1]
+}
+
+// the number of bytes a version can hold in byte mode
+func byteCapacity(version int) int {
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	return (versionsM[version].dataCodewords()*8 - 4 - countBits) / 8
+}
+
+// Code is an encoded QR code.  modules holds its modules, true for dark,
+// indexed [row][column].
+type Code struct {
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+// Encode encodes data as a QR code in byte mode at error correction level
+// M, in the smallest version that holds it
+func Encode(data []byte) (*Code, error) {
+	version := 0
+	for v := 1; v < len(versionsM); v++ {
+		if len(data) <= byteCapacity(v) {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, errors.New("Too much data for a QR code")
+	}
+
+	codewords := interleavedCodewords(data, version)
+
+	size := version*4 + 17
+	qr := &Code{size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for ix := range qr.modules {
+		qr.modules[ix] = make([]bool, size)
+		qr.function[ix] = make([]bool, size)
+	}
+
+	qr.drawFunctionPatterns(version)
+	qr.drawCodewords(codewords)
+
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		qr.applyMask(mask)
+		qr.drawFormatBits(mask)
+		if penalty := qr.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		// masking is its own inverse
+		qr.applyMask(mask)
+	}
+	qr.applyMask(bestMask)
+	qr.drawFormatBits(bestMask)
+
+	return qr, nil
+}
+
+// the data and error correction codewords for data, interleaved in the
+// order they're placed in the symbol
+func interleavedCodewords(data []byte, version int) []byte {
+	blocks := versionsM[version]
+	capacity := blocks.dataCodewords()
+
+	// mode indicator, character count, data, terminator, then padding
+	var bits bitBuffer
+	bits.append(0x4, 4)
+	if version >= 10 {
+		bits.append(len(data), 16)
+	} else {
+		bits.append(len(data), 8)
+	}
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	terminator := capacity*8 - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+
+	dataCodewords := bits.bytes()
+	for pad := byte(0xEC); len(dataCodewords) < capacity; pad ^= 0xEC ^ 0x11 {
+		dataCodewords = append(dataCodewords, pad)
+	}
+
+	divisor := reedSolomonDivisor(blocks.ecPerBlock)
+	var dataBlocks, ecBlocks [][]byte
+	for group := 0; group < 2; group++ {
+		for ix := 0; ix < blocks.groupBlocks[group]; ix++ {
+			block := dataCodewords[:blocks.groupData[group]]
+			dataCodewords = dataCodewords[blocks.groupData[group]:]
+			dataBlocks = append(dataBlocks, block)
+			ecBlocks = append(ecBlocks, reedSolomonRemainder(block, divisor))
+		}
+	}
+
+	var ret []byte
+	for ix := 0; ix < blocks.groupData[0] || ix < blocks.groupData[1]; ix++ {
+		for _, block := range dataBlocks {
+			if ix < len(block) {
+				ret = append(ret, block[ix])
+			}
+		}
+	}
+	for ix := 0; ix < blocks.ecPerBlock; ix++ {
+		for _, block := range ecBlocks {
+			ret = append(ret, block[ix])
+		}
+	}
+	return ret
+}
+
+// a sequence of bits, most significant first
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value int, n int) {
+	for ix := n - 1; ix >= 0; ix-- {
+		*b = append(*b, (value>>uint(ix))&1 == 1)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	ret := make([]byte, (len(b)+7)/8)
+	for ix, bit := range b {
+		if bit {
+			ret[ix/8] |= 0x80 >> uint(ix%8)
+		}
+	}
+	return ret
+}
+
+// multiply in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x byte, y byte) byte {
+	var z byte
+	for ix := 7; ix >= 0; ix-- {
+		carry := z >> 7
+		z <<= 1
+		if carry == 1 {
+			z ^= 0x1D
+		}
+		if (y>>uint(ix))&1 == 1 {
+			z ^= x
+		}
+	}
+	return z
+}
+
+// the coefficients, highest power first and without the leading 1, of the
+// Reed-Solomon generator polynomial of the given degree
+func reedSolomonDivisor(degree int) []byte {
+	ret := make([]byte, degree)
+	ret[degree-1] = 1
+
+	root := byte(1)
+	for ix := 0; ix < degree; ix++ {
+		for jx := range ret {
+			ret[jx] = gfMultiply(ret[jx], root)
+			if jx+1 < len(ret) {
+				ret[jx] ^= ret[jx+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return ret
+}
+
+// the Reed-Solomon error correction codewords for data
+func reedSolomonRemainder(data []byte, divisor []byte) []byte {
+	ret := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ ret[0]
+		copy(ret, ret[1:])
+		ret[len(ret)-1] = 0
+		for ix := range ret {
+			ret[ix] ^= gfMultiply(divisor[ix], factor)
+		}
+	}
+	return ret
+}
+
+func (qr *Code) setFunction(row int, col int, dark bool) {
+	qr.modules[row][col] = dark
+	qr.function[row][col] = true
+}
+
+// draw the finder, timing and alignment patterns and the version
+// information, and reserve the format information areas
+func (qr *Code) drawFunctionPatterns(version int) {
+	for ix := 0; ix < qr.size; ix++ {
+		qr.setFunction(6, ix, ix%2 == 0)
+		qr.setFunction(ix, 6, ix%2 == 0)
+	}
+
+	qr.drawFinder(3, 3)
+	qr.drawFinder(3, qr.size-4)
+	qr.drawFinder(qr.size-4, 3)
+
+	positions := alignmentPositions[version]
+	last := len(positions) - 1
+	for ix, row := range positions {
+		for jx, col := range positions {
+			// the corners with finder patterns
+			if (ix == 0 && jx == 0) || (ix == 0 && jx == last) || (ix == last && jx == 0) {
+				continue
+			}
+			for dr := -2; dr <= 2; dr++ {
+				for dc := -2; dc <= 2; dc++ {
+					qr.setFunction(row+dr, col+dc, max(abs(dr), abs(dc)) != 1)
+				}
+			}
+		}
+	}
+
+	// reserve the format information with dummy bits; drawFormatBits
+	// fills them in once a mask is chosen
+	qr.drawFormatBits(0)
+
+	if version >= 7 {
+		rem := version
+		for ix := 0; ix < 12; ix++ {
+			rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+		}
+		bits := version<<12 | rem
+		for ix := 0; ix < 18; ix++ {
+			dark := (bits>>uint(ix))&1 == 1
+			a, b := qr.size-11+ix%3, ix/3
+			qr.setFunction(b, a, dark)
+			qr.setFunction(a, b, dark)
+		}
+	}
+}
+
+// draw a finder pattern, and its separator, centred on (row, col)
+func (qr *Code) drawFinder(row int, col int) {
+	for dr := -4; dr <= 4; dr++ {
+		for dc := -4; dc <= 4; dc++ {
+			r, c := row+dr, col+dc
+			if r < 0 || r >= qr.size || c < 0 || c >= qr.size {
+				continue
+			}
+			dist := max(abs(dr), abs(dc))
+			qr.setFunction(r, c, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// draw the two copies of the format information for error correction level
+// M and the given mask, and the dark module beside the bottom-left copy
+func (qr *Code) drawFormatBits(mask int) {
+	// level M is 00
+	data := mask
+	rem := data
+	for ix := 0; ix < 10; ix++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(ix int) bool {
+		return (bits>>uint(ix))&1 == 1
+	}
+
+	for ix := 0; ix <= 5; ix++ {
+		qr.setFunction(ix, 8, bit(ix))
+	}
+	qr.setFunction(7, 8, bit(6))
+	qr.setFunction(8, 8, bit(7))
+	qr.setFunction(8, 7, bit(8))
+	for ix := 9; ix < 15; ix++ {
+		qr.setFunction(8, 14-ix, bit(ix))
+	}
+
+	for ix := 0; ix < 8; ix++ {
+		qr.setFunction(8, qr.size-1-ix, bit(ix))
+	}
+	for ix := 8; ix < 15; ix++ {
+		qr.setFunction(qr.size-15+ix, 8, bit(ix))
+	}
+	qr.setFunction(qr.size-8, 8, true)
+}
+
+// place the codewords in the modules that aren't function patterns, in
+// two-column strips zigzagging up and down from the bottom right
+func (qr *Code) drawCodewords(codewords []byte) {
+	ix := 0
+	for right := qr.size - 1; right >= 1; right -= 2 {
+		// the vertical timing pattern
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < qr.size; vert++ {
+			row := vert
+			if upward {
+				row = qr.size - 1 - vert
+			}
+			for jx := 0; jx < 2; jx++ {
+				col := right - jx
+				if qr.function[row][col] {
+					continue
+				}
+				// any remainder bits are left light
+				if ix < len(codewords)*8 {
+					qr.modules[row][col] = (codewords[ix/8]>>uint(7-ix%8))&1 == 1
+					ix++
+				}
+			}
+		}
+	}
+}
+
+// flip the modules outside the function patterns that the mask selects
+func (qr *Code) applyMask(mask int) {
+	for row := 0; row < qr.size; row++ {
+		for col := 0; col < qr.size; col++ {
+			if qr.function[row][col] {
+				continue
+			}
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (row+col)%2 == 0
+			case 1:
+				flip = row%2 == 0
+			case 2:
+				flip = col%3 == 0
+			case 3:
+				flip = (row+col)%3 == 0
+			case 4:
+				flip = (row/2+col/3)%2 == 0
+			case 5:
+				flip = row*col%2+row*col%3 == 0
+			case 6:
+				flip = (row*col%2+row*col%3)%2 == 0
+			case 7:
+				flip = ((row+col)%2+row*col%3)%2 == 0
+			}
+			if flip {
+				qr.modules[row][col] = !qr.modules[row][col]
+			}
+		}
+	}
+}
+
+// score how hard the symbol would be to read, lower being better, by the
+// four penalty rules used to choose a mask
+func (qr *Code) penalty() int {
+	penalty := 0
+	at := func(row int, col int, transpose bool) bool {
+		if transpose {
+			return qr.modules[col][row]
+		}
+		return qr.modules[row][col]
+	}
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+
+	for _, transpose := range []bool{false, true} {
+		for row := 0; row < qr.size; row++ {
+			// runs of five or more modules of one colour
+			run := 1
+			for col := 1; col <= qr.size; col++ {
+				if col < qr.size && at(row, col, transpose) == at(row, col-1, transpose) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += 3 + run - 5
+				}
+				run = 1
+			}
+
+			// patterns that look like part of a finder
+			for col := 0; col+11 <= qr.size; col++ {
+				for _, pattern := range finderLike {
+					match := true
+					for ix, dark := range pattern {
+						if at(row, col+ix, transpose) != dark {
+							match = false
+							break
+						}
+					}
+					if match {
+						penalty += 40
+					}
+				}
+			}
+		}
+	}
+
+	// 2x2 blocks of one colour
+	dark := 0
+	for row := 0; row < qr.size; row++ {
+		for col := 0; col < qr.size; col++ {
+			if qr.modules[row][col] {
+				dark++
+			}
+			if row+1 < qr.size && col+1 < qr.size {
+				c := qr.modules[row][col]
+				if qr.modules[row][col+1] == c && qr.modules[row+1][col] == c && qr.modules[row+1][col+1] == c {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	// how far the proportion of dark modules is from half
+	total := qr.size * qr.size
+	penalty += abs(dark*100/total-50) / 5 * 10
+
+	return penalty
+}
+
+// PNG renders the code as a black and white PNG, with each module scale pixels
+// square and the four-module quiet zone scanners need around it
+func (qr *Code) PNG(scale int) ([]byte, error) {
+	const quietZone = 4
+	width := (qr.size + 2*quietZone) * scale
+
+	img := image.NewPaletted(image.Rect(0, 0, width, width), color.Palette{color.White, color.Black})
+	for row := 0; row < qr.size; row++ {
+		for col := 0; col < qr.size; col++ {
+			if !qr.modules[row][col] {
+				continue
+			}
+			for y := 0; y < scale; y++ {
+				for x := 0; x < scale; x++ {
+					img.SetColorIndex((col+quietZone)*scale+x, (row+quietZone)*scale+y, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
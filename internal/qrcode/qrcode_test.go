@@ -0,0 +1,279 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// the worked "HELLO WORLD" 1-M example from the QR code specification
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	got := reedSolomonRemainder(data, reedSolomonDivisor(len(want)))
+	if !bytes.Equal(got, want) {
+		t.Errorf("reedSolomonRemainder() = %v, want %v", got, want)
+	}
+}
+
+func TestEncode_ReferenceSymbols(t *testing.T) {
+	// the reference symbols were made by an independent encoder,
+	// github.com/boombuler/barcode/qr at level M in byte mode, one text line
+	// per row with # for dark modules
+	const long = "otpauth://totp/ACME%20Co:john.doe@email.com?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ" +
+		"&issuer=ACME%20Co&algorithm=SHA1&digits=6&period=30&x=abcdefghijklmnopqrstuvwxyz" +
+		"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz"
+	tests := []struct {
+		reference string
+		data      string
+	}{
+		{reference: "hello.txt", data: "hello"},
+		{reference: "totp.txt", data: "otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"},
+		{reference: "version7.txt", data: long[:122]},
+		{reference: "version10.txt", data: long[:213]},
+	}
+	for _, tt := range tests {
+		reference, err := ioutil.ReadFile("../../testdata/qrcode/" + tt.reference)
+		if err != nil {
+			t.Fatalf("Failed to read reference symbol: %v", err)
+		}
+
+		qr, err := Encode([]byte(tt.data))
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+
+		var got strings.Builder
+		for _, row := range qr.modules {
+			for _, dark := range row {
+				if dark {
+					got.WriteByte('#')
+				} else {
+					got.WriteByte('.')
+				}
+			}
+			got.WriteByte('\n')
+		}
+		if got.String() != string(reference) {
+			t.Errorf("Encode(%q) =\n%s\nwant %s:\n%s", tt.data, got.String(), tt.reference, reference)
+		}
+	}
+}
+
+// read the 15 format information bits from the copy around the top-left
+// finder pattern, in the order drawFormatBits draws them
+func readFormatBits(qr *Code) int {
+	var coords [][2]int
+	for ix := 0; ix <= 5; ix++ {
+		coords = append(coords, [2]int{ix, 8})
+	}
+	coords = append(coords, [2]int{7, 8}, [2]int{8, 8}, [2]int{8, 7})
+	for ix := 9; ix < 15; ix++ {
+		coords = append(coords, [2]int{8, 14 - ix})
+	}
+
+	bits := 0
+	for ix, c := range coords {
+		if qr.modules[c[0]][c[1]] {
+			bits |= 1 << uint(ix)
+		}
+	}
+	return bits
+}
+
+func TestEncode_FormatBits(t *testing.T) {
+	qr, err := Encode([]byte("otpauth://totp/Example?secret=JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	// the specification's format information for level M, mask 0 ...
+	// mask 7, before masking with 0x5412
+	valid := []int{0x0000, 0x0537, 0x0A6E, 0x0F59, 0x11EB, 0x14DC, 0x1B85, 0x1EB2}
+	format := readFormatBits(qr) ^ 0x5412
+	mask := format >> 10
+	if mask > 7 || format != valid[mask] {
+		t.Fatalf("Format bits %015b aren't level M with a valid BCH code", format)
+	}
+
+	// the second copy, along the bottom and the right
+	second := 0
+	for ix := 0; ix < 8; ix++ {
+		if qr.modules[8][qr.size-1-ix] {
+			second |= 1 << uint(ix)
+		}
+	}
+	for ix := 8; ix < 15; ix++ {
+		if qr.modules[qr.size-15+ix][8] {
+			second |= 1 << uint(ix)
+		}
+	}
+	if second^0x5412 != format {
+		t.Errorf("Second copy of format bits %015b, want %015b", second^0x5412, format)
+	}
+	if !qr.modules[qr.size-8][8] {
+		t.Error("Dark module is light")
+	}
+}
+
+func TestEncode_VersionInfo(t *testing.T) {
+	// 120 bytes needs version 7, the first with version information
+	qr, err := Encode(bytes.Repeat([]byte("x"), 120))
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if qr.size != 45 {
+		t.Fatalf("Got size %d, want 45 (version 7)", qr.size)
+	}
+
+	bits := 0
+	for ix := 0; ix < 18; ix++ {
+		if qr.modules[ix/3][qr.size-11+ix%3] {
+			bits |= 1 << uint(ix)
+		}
+	}
+	// the specification's version information for version 7
+	if bits != 0x07C94 {
+		t.Errorf("Version information = %018b, want %018b", bits, 0x07C94)
+	}
+}
+
+// read the codewords back out of a code, undoing the mask, and split them
+// into the data and error correction blocks they were interleaved from
+func readBlocks(t *testing.T, qr *Code, version int) ([][]byte, [][]byte) {
+	t.Helper()
+
+	mask := (readFormatBits(qr) ^ 0x5412) >> 10
+	unmasked := &Code{size: qr.size, modules: make([][]bool, qr.size), function: make([][]bool, qr.size)}
+	for ix := range unmasked.modules {
+		unmasked.modules[ix] = append([]bool{}, qr.modules[ix]...)
+		unmasked.function[ix] = make([]bool, qr.size)
+	}
+	functions := &Code{size: qr.size, modules: make([][]bool, qr.size), function: unmasked.function}
+	for ix := range functions.modules {
+		functions.modules[ix] = make([]bool, qr.size)
+	}
+	functions.drawFunctionPatterns(version)
+	unmasked.applyMask(mask)
+
+	var bits bitBuffer
+	for right := qr.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < qr.size; vert++ {
+			row := vert
+			if (right+1)&2 == 0 {
+				row = qr.size - 1 - vert
+			}
+			for _, col := range []int{right, right - 1} {
+				if !unmasked.function[row][col] {
+					bits = append(bits, unmasked.modules[row][col])
+				}
+			}
+		}
+	}
+	codewords := bits.bytes()
+
+	blocks := versionsM[version]
+	var lengths []int
+	for group := 0; group < 2; group++ {
+		for ix := 0; ix < blocks.groupBlocks[group]; ix++ {
+			lengths = append(lengths, blocks.groupData[group])
+		}
+	}
+	dataBlocks := make([][]byte, len(lengths))
+	ecBlocks := make([][]byte, len(lengths))
+	for ix := 0; ix < blocks.groupData[0] || ix < blocks.groupData[1]; ix++ {
+		for jx, length := range lengths {
+			if ix < length {
+				dataBlocks[jx] = append(dataBlocks[jx], codewords[0])
+				codewords = codewords[1:]
+			}
+		}
+	}
+	for ix := 0; ix < blocks.ecPerBlock; ix++ {
+		for jx := range lengths {
+			ecBlocks[jx] = append(ecBlocks[jx], codewords[0])
+			codewords = codewords[1:]
+		}
+	}
+	return dataBlocks, ecBlocks
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	for version := 1; version < len(versionsM); version++ {
+		data := []byte(strings.Repeat("otpauth://totp/", 20)[:byteCapacity(version)])
+
+		qr, err := Encode(data)
+		if err != nil {
+			t.Fatalf("Encode() of %d bytes error = %v", len(data), err)
+		}
+		if qr.size != version*4+17 {
+			t.Errorf("Encode() of %d bytes gave size %d, want %d", len(data), qr.size, version*4+17)
+			continue
+		}
+
+		dataBlocks, ecBlocks := readBlocks(t, qr, version)
+		var dataCodewords []byte
+		for ix, block := range dataBlocks {
+			want := reedSolomonRemainder(block, reedSolomonDivisor(versionsM[version].ecPerBlock))
+			if !bytes.Equal(ecBlocks[ix], want) {
+				t.Errorf("Version %d block %d has error correction %v, want %v", version, ix, ecBlocks[ix], want)
+			}
+			dataCodewords = append(dataCodewords, block...)
+		}
+
+		// byte mode, then the length and the data
+		countBits := 8
+		if version >= 10 {
+			countBits = 16
+		}
+		var bits bitBuffer
+		bits.append(0x4, 4)
+		bits.append(len(data), countBits)
+		for _, b := range data {
+			bits.append(int(b), 8)
+		}
+		// the last byte may run into the terminator
+		want := bits.bytes()[:len(bits)/8]
+		if !bytes.Equal(dataCodewords[:len(want)], want) {
+			t.Errorf("Version %d data codewords = %v, want prefix %v", version, dataCodewords, want)
+		}
+	}
+
+	if _, err := Encode(make([]byte, byteCapacity(10)+1)); err == nil {
+		t.Error("Encode() of too much data error = nil, want error")
+	}
+}
+
+func TestPNG(t *testing.T) {
+	qr, err := Encode([]byte("hello"))
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	data, err := qr.PNG(4)
+	if err != nil {
+		t.Fatalf("PNG() error = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	width := (qr.size + 8) * 4
+	if bounds := img.Bounds(); bounds.Dx() != width || bounds.Dy() != width {
+		t.Fatalf("PNG is %v, want %dx%d", bounds, width, width)
+	}
+	// quiet zone, then the corner of the top-left finder pattern
+	if r, _, _, _ := img.At(0, 0).RGBA(); r == 0 {
+		t.Error("Quiet zone is dark")
+	}
+	if r, _, _, _ := img.At(16, 16).RGBA(); r != 0 {
+		t.Error("Finder pattern corner is light")
+	}
+}
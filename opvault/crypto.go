@@ -0,0 +1,111 @@
+package opvault
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAuthenticationFailed is returned when an HMAC doesn't verify, which for
+// the profile keys means the password is wrong and for anything else means
+// the data has been corrupted or tampered with
+var ErrAuthenticationFailed = errors.New("HMAC verification failed")
+
+// a pair of keys: one for AES-256-CBC and one for HMAC-SHA256
+type keyPair struct {
+	encKey []byte
+	macKey []byte
+}
+
+// decode a base64 field from the vault, tolerating the trailing NULs
+// 1Password writes after some strings and missing padding
+func decodeBase64(str string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(str, "\u0000="))
+}
+
+func splitKeyPair(raw []byte) (keyPair, error) {
+	if len(raw) != 64 {
+		return keyPair{}, fmt.Errorf("Invalid key pair length %d", len(raw))
+	}
+	return keyPair{encKey: raw[0:32], macKey: raw[32:64]}, nil
+}
+
+// check the trailing HMAC-SHA256 on blob, returning the authenticated data
+func verifyHMAC(blob []byte, macKey []byte) ([]byte, error) {
+	if len(blob) < sha256.Size {
+		return nil, fmt.Errorf("Data too short for HMAC: %d bytes", len(blob))
+	}
+
+	data := blob[:len(blob)-sha256.Size]
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(data)
+	if !hmac.Equal(mac.Sum(nil), blob[len(blob)-sha256.Size:]) {
+		return nil, ErrAuthenticationFailed
+	}
+	return data, nil
+}
+
+// decrypt AES-256-CBC without removing padding; opvault does its own
+func cbcDecrypt(blob []byte, key []byte, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blob)%block.BlockSize() != 0 {
+		return nil, errors.New("Input is not a multiple of blocksize")
+	}
+
+	ret := make([]byte, len(blob))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(ret, blob)
+	return ret, nil
+}
+
+// opdata01 is "opdata01", an 8 byte little-endian plaintext length, a 16
+// byte IV, the ciphertext, and an HMAC-SHA256 over all of the preceding.  The
+// plaintext is prefixed with random padding up to the block size.
+func decryptOpdata(blob []byte, keys keyPair) ([]byte, error) {
+	data, err := verifyHMAC(blob, keys.macKey)
+	if err != nil {
+		return nil, err
+	}
+
+	header := []byte("opdata01")
+	if len(data) < len(header)+8+aes.BlockSize || !bytes.Equal(data[0:len(header)], header) {
+		return nil, errors.New("Not an opdata01 blob")
+	}
+
+	data = data[len(header):]
+	length := binary.LittleEndian.Uint64(data[0:8])
+	iv := data[8 : 8+aes.BlockSize]
+
+	plaintext, err := cbcDecrypt(data[8+aes.BlockSize:], keys.encKey, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	if length > uint64(len(plaintext)) {
+		return nil, fmt.Errorf("Invalid opdata01 length %d", length)
+	}
+	return plaintext[uint64(len(plaintext))-length:], nil
+}
+
+// the master and overview keys are stored as opdata01 blobs of random bytes;
+// the actual key pair is the SHA-512 of the decrypted bytes
+func decryptProfileKey(blob []byte, derived keyPair) (keyPair, error) {
+	raw, err := decryptOpdata(blob, derived)
+	if err != nil {
+		return keyPair{}, err
+	}
+
+	sum := sha512.Sum512(raw)
+	return splitKeyPair(sum[:])
+}
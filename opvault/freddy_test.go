@@ -0,0 +1,53 @@
+package opvault
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// AgileBits' published sample vault, whose password is "freddy".  Unlike
+// the example1 fixture it was written by 1Password itself, so it checks the
+// key derivation, opdata01 and item HMAC handling against the real format.
+// It isn't checked in; unpack freddy-2013-12-04.tar.gz from
+// https://cache.agilebits.com/security-kb/ into testdata/opvault/freddy to
+// run this test.
+const freddyPath = "../testdata/opvault/freddy/onepassword_data"
+
+func TestFreddy(t *testing.T) {
+	if _, err := os.Stat(freddyPath); err != nil {
+		t.Skipf("Sample vault not present: %v", err)
+	}
+
+	vault, err := NewOPVault(freddyPath)
+	if err != nil {
+		t.Fatalf("Error creating opvault from sample vault: %v", err)
+	}
+	if vault.Length() == 0 {
+		t.Fatalf("Sample vault has no items")
+	}
+
+	err = vault.Unlock("not freddy")
+	if !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("Unlock() with wrong password error = %v, want ErrAuthenticationFailed", err)
+	}
+
+	err = vault.Unlock("freddy")
+	if err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+
+	for _, b := range vault.bands {
+		for uuid := range b {
+			_, err := vault.Overview(uuid)
+			if err != nil {
+				t.Errorf("Overview(%s) error = %v", uuid, err)
+			}
+
+			_, err = vault.DecryptItem(uuid)
+			if err != nil {
+				t.Errorf("DecryptItem(%s) error = %v", uuid, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package opvault
+
+import (
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrLocked is returned when decryption is attempted before Unlock
+var ErrLocked = errors.New("vault is locked")
+
+// OPVault items are protected by two different keys:
+//
+//   - the overview ("o") holds the non-secret summary shown in lists: title,
+//     URLs, tags, the password strength and so on.  It is encrypted with the
+//     profile's overview key, so every overview can be read right after
+//     unlocking.
+//   - the details ("d") hold the secrets themselves.  They are encrypted with
+//     a per-item key pair, which is in turn stored in the item ("k")
+//     encrypted with the profile's master key.
+//
+// This is the opvault analog of the AgileKeychain's openContents, except
+// that the overview is encrypted too.
+
+// Overview decrypts and returns the overview of the item with the given uuid
+func (v *OPVault) Overview(uuid string) (map[string]interface{}, error) {
+	if !v.Unlocked() {
+		return nil, ErrLocked
+	}
+
+	item, err := v.verifiedBandItem(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return decryptJSON(item.O, *v.overviewKeys)
+}
+
+// DecryptItem decrypts and returns the details of the item with the given
+// uuid
+func (v *OPVault) DecryptItem(uuid string) (map[string]interface{}, error) {
+	if !v.Unlocked() {
+		return nil, ErrLocked
+	}
+
+	item, err := v.verifiedBandItem(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	itemKeys, err := v.itemKeys(item)
+	if err != nil {
+		return nil, err
+	}
+
+	return decryptJSON(item.D, itemKeys)
+}
+
+// find the band entry for an item and check its HMAC, which covers every
+// field but itself: each name followed by its value, in name order, with
+// true and false written as 1 and 0.  It's keyed with the overview key pair's
+// HMAC key.
+func (v *OPVault) verifiedBandItem(uuid string) (bandItem, error) {
+	item, err := v.bandItem(uuid)
+	if err != nil {
+		return bandItem{}, err
+	}
+
+	want, err := decodeBase64(item.HMAC)
+	if err != nil {
+		return bandItem{}, err
+	}
+
+	names := make([]string, 0, len(item.fields))
+	for name := range item.fields {
+		if name != "hmac" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	mac := hmac.New(sha256.New, v.overviewKeys.macKey)
+	for _, name := range names {
+		mac.Write([]byte(name))
+		switch value := item.fields[name].(type) {
+		case string:
+			mac.Write([]byte(value))
+		case json.Number:
+			mac.Write([]byte(value.String()))
+		case bool:
+			if value {
+				mac.Write([]byte("1"))
+			} else {
+				mac.Write([]byte("0"))
+			}
+		default:
+			return bandItem{}, fmt.Errorf("Unexpected value for %s in item %s", name, item.UUID)
+		}
+	}
+	if !hmac.Equal(mac.Sum(nil), want) {
+		return bandItem{}, fmt.Errorf("Failed to verify item %s: %w", item.UUID, ErrAuthenticationFailed)
+	}
+	return item, nil
+}
+
+// the item key is a 16 byte IV, 64 bytes of key pair encrypted with the
+// master key, and an HMAC-SHA256 over both
+func (v *OPVault) itemKeys(item bandItem) (keyPair, error) {
+	blob, err := decodeBase64(item.K)
+	if err != nil {
+		return keyPair{}, err
+	}
+
+	data, err := verifyHMAC(blob, v.masterKeys.macKey)
+	if err != nil {
+		return keyPair{}, fmt.Errorf("Failed to verify key for item %s: %w", item.UUID, err)
+	}
+
+	if len(data) < aes.BlockSize {
+		return keyPair{}, fmt.Errorf("Invalid key for item %s", item.UUID)
+	}
+
+	raw, err := cbcDecrypt(data[aes.BlockSize:], v.masterKeys.encKey, data[:aes.BlockSize])
+	if err != nil {
+		return keyPair{}, err
+	}
+
+	return splitKeyPair(raw)
+}
+
+// decrypt a base64 opdata01 blob holding a JSON object
+func decryptJSON(encoded string, keys keyPair) (map[string]interface{}, error) {
+	blob, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := decryptOpdata(blob, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret map[string]interface{}
+	err = json.Unmarshal(plaintext, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
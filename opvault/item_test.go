@@ -0,0 +1,104 @@
+package opvault
+
+import (
+	"errors"
+	"testing"
+)
+
+func unlockedFixture(t *testing.T) *OPVault {
+	t.Helper()
+
+	vault, err := NewOPVault(fixturePath)
+	if err != nil {
+		t.Fatalf("Error creating opvault from fixture: %v", err)
+	}
+
+	err = vault.Unlock("opvault")
+	if err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+	return vault
+}
+
+func TestOverview(t *testing.T) {
+	vault := unlockedFixture(t)
+
+	overview, err := vault.Overview("0A6E4B7E2F8D4C1B9E3A5F7D2C8B1E40")
+	if err != nil {
+		t.Fatalf("Overview() error = %v", err)
+	}
+
+	if overview["title"] != "Example Login" {
+		t.Errorf("Got wrong title: %v", overview["title"])
+	}
+	if overview["url"] != "https://www.example.com/login" {
+		t.Errorf("Got wrong url: %v", overview["url"])
+	}
+}
+
+func TestDecryptItem(t *testing.T) {
+	vault := unlockedFixture(t)
+
+	details, err := vault.DecryptItem("A1B2C3D4E5F60718293A4B5C6D7E8F90")
+	if err != nil {
+		t.Fatalf("DecryptItem() error = %v", err)
+	}
+	if details["password"] != "hunter2" {
+		t.Errorf("Got wrong password: %v", details["password"])
+	}
+
+	details, err = vault.DecryptItem("0A6E4B7E2F8D4C1B9E3A5F7D2C8B1E40")
+	if err != nil {
+		t.Fatalf("DecryptItem() error = %v", err)
+	}
+	fields, ok := details["fields"].([]interface{})
+	if !ok || len(fields) != 2 {
+		t.Errorf("Got wrong fields: %v", details["fields"])
+	}
+
+	_, err = vault.DecryptItem("B0000000000000000000000000000000")
+	if err == nil {
+		t.Errorf("DecryptItem() of nonexistent item did not fail")
+	}
+}
+
+func TestDecrypt_TamperedItem(t *testing.T) {
+	vault := unlockedFixture(t)
+
+	// moving an item out of the trash must break its HMAC
+	item := vault.bands["F"]["F0E1D2C3B4A5968778695A4B3C2D1E0F"]
+	item.fields["trashed"] = false
+	vault.bands["F"]["F0E1D2C3B4A5968778695A4B3C2D1E0F"] = item
+
+	_, err := vault.Overview("F0E1D2C3B4A5968778695A4B3C2D1E0F")
+	if !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("Overview() of tampered item error = %v, want ErrAuthenticationFailed", err)
+	}
+
+	_, err = vault.DecryptItem("F0E1D2C3B4A5968778695A4B3C2D1E0F")
+	if !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("DecryptItem() of tampered item error = %v, want ErrAuthenticationFailed", err)
+	}
+
+	_, err = vault.DecryptItem("a1b2c3d4e5f60718293a4b5c6d7e8f90")
+	if err != nil {
+		t.Errorf("DecryptItem() of lower case uuid error = %v", err)
+	}
+}
+
+func TestDecrypt_Locked(t *testing.T) {
+	vault, err := NewOPVault(fixturePath)
+	if err != nil {
+		t.Fatalf("Error creating opvault from fixture: %v", err)
+	}
+
+	_, err = vault.Overview("0A6E4B7E2F8D4C1B9E3A5F7D2C8B1E40")
+	if !errors.Is(err, ErrLocked) {
+		t.Errorf("Overview() on locked vault error = %v, want ErrLocked", err)
+	}
+
+	_, err = vault.DecryptItem("0A6E4B7E2F8D4C1B9E3A5F7D2C8B1E40")
+	if !errors.Is(err, ErrLocked) {
+		t.Errorf("DecryptItem() on locked vault error = %v, want ErrLocked", err)
+	}
+}
@@ -0,0 +1,227 @@
+package opvault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// OPVault represents a 1password OPVault
+// see design discussion here: https://support.1password.com/opvault-design/
+type OPVault struct {
+	baseDir      string
+	profile      profile
+	bands        map[string]band
+	masterKeys   *keyPair
+	overviewKeys *keyPair
+}
+
+// a band is the contents of one band_X.js file, keyed by item uuid
+type band map[string]bandItem
+
+// bandItem is an item as stored in a band file
+type bandItem struct {
+	UUID     string
+	Category string
+	Created  int64
+	Updated  int64
+	Tx       int64
+	Folder   string
+	Trashed  bool
+	Fave     int64
+	HMAC     string
+	K        string
+	O        string
+	D        string
+
+	// every field as stored, which the item's HMAC covers
+	fields map[string]interface{}
+}
+
+// Item is the unencrypted metadata of an item in the vault
+type Item struct {
+	UUID     string
+	Category string
+	Created  time.Time
+	Updated  time.Time
+	Folder   string
+	Trashed  bool
+}
+
+// items are spread across 16 band files by the first hex digit of their uuid
+const bandDigits = "0123456789ABCDEF"
+
+// NewOPVault creates a new OPVault object, given a path
+// returns an error if path doesn't exist or is not a directory
+func NewOPVault(vaultPath string) (*OPVault, error) {
+	if !path.IsAbs(vaultPath) {
+		dir, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+
+		vaultPath = path.Join(dir, vaultPath)
+	}
+
+	ret := &OPVault{
+		baseDir: vaultPath,
+	}
+
+	fileinfo, err := os.Stat(vaultPath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("Non-existent OPVault path %s: %w", vaultPath, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !fileinfo.IsDir() {
+		return nil, fmt.Errorf("OPVault path %s not a directory", vaultPath)
+	}
+
+	err = ret.loadProfile()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ret.loadBands()
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// IsOPVault reports whether vaultPath is laid out as an OPVault, with a
+// default/profile.js, so that callers handed a vault of unknown format can
+// tell it from an AgileKeychain (see agilekeychain.IsAgileKeychain) before
+// opening it.  Only the layout is checked.
+func IsOPVault(vaultPath string) bool {
+	fileinfo, err := os.Stat(path.Join(vaultPath, "default", "profile.js"))
+	return err == nil && fileinfo.Mode().IsRegular()
+}
+
+// name of the band file an item with the given uuid lives in
+func bandName(uuid string) (string, error) {
+	if uuid == "" {
+		return "", fmt.Errorf("Empty item uuid")
+	}
+
+	digit := strings.ToUpper(uuid[0:1])
+	if !strings.Contains(bandDigits, digit) {
+		return "", fmt.Errorf("Invalid item uuid %s", uuid)
+	}
+	return digit, nil
+}
+
+// load every band_X.js file present in the profile directory.  Bands are
+// optional; a vault with no items in a band has no file for it.
+func (v *OPVault) loadBands() error {
+	v.bands = make(map[string]band, len(bandDigits))
+
+	for _, digit := range bandDigits {
+		name := string(digit)
+		bandPath := path.Join(v.baseDir, "default", "band_"+name+".js")
+
+		data, err := ioutil.ReadFile(bandPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		b, err := parseBand(data)
+		if err != nil {
+			return fmt.Errorf("Failed to parse band %s: %v", name, err)
+		}
+
+		for uuid := range b {
+			itemBand, err := bandName(uuid)
+			if err != nil {
+				return err
+			}
+			if itemBand != name {
+				return fmt.Errorf("Item %s found in band %s, belongs in band %s", uuid, name, itemBand)
+			}
+		}
+
+		v.bands[name] = b
+	}
+
+	return nil
+}
+
+// band files are JSONP: the item map wrapped in a call to ld(...);
+func parseBand(data []byte) (band, error) {
+	data = bytes.TrimSpace(data)
+	data = bytes.TrimPrefix(data, []byte("ld("))
+	data = bytes.TrimSuffix(data, []byte(";"))
+	data = bytes.TrimSuffix(data, []byte(")"))
+
+	var ret band
+	err := json.Unmarshal(data, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep numbers as written, for the HMAC
+	var fields map[string]map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&fields)
+	if err != nil {
+		return nil, err
+	}
+	for uuid, item := range ret {
+		item.fields = fields[uuid]
+		ret[uuid] = item
+	}
+	return ret, nil
+}
+
+// find the raw band entry for an item.  uuids are stored in upper case but
+// matched in any case.
+func (v *OPVault) bandItem(uuid string) (bandItem, error) {
+	name, err := bandName(uuid)
+	if err != nil {
+		return bandItem{}, err
+	}
+
+	item, ok := v.bands[name][strings.ToUpper(uuid)]
+	if !ok {
+		return bandItem{}, fmt.Errorf("No item with uuid %s", uuid)
+	}
+	return item, nil
+}
+
+// GetItem returns the unencrypted metadata of the item with the given uuid
+func (v *OPVault) GetItem(uuid string) (*Item, error) {
+	item, err := v.bandItem(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Item{
+		UUID:     item.UUID,
+		Category: item.Category,
+		Created:  time.Unix(item.Created, 0),
+		Updated:  time.Unix(item.Updated, 0),
+		Folder:   item.Folder,
+		Trashed:  item.Trashed,
+	}, nil
+}
+
+// Length of the vault
+func (v *OPVault) Length() int {
+	ret := 0
+	for _, b := range v.bands {
+		ret += len(b)
+	}
+	return ret
+}
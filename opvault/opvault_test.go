@@ -0,0 +1,139 @@
+package opvault
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+const fixturePath = "../testdata/opvault/example1/passync.opvault"
+
+func TestNewOPVault_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{
+			name: "Test nonexistent directory",
+			path: "/nonexist4329489erjgar",
+		},
+		{
+			name: "Test existent path but not a directory",
+			path: "../testdata/agilekeychain/file",
+		},
+		{
+			name: "Test path that can't be stat'd",
+			path: "../testdata/agilekeychain/file/default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOPVault(tt.path)
+			if err == nil {
+				t.Errorf("NewOPVault() = %v, want error", got)
+			}
+		})
+	}
+}
+
+func TestNewOPVault_Example1(t *testing.T) {
+	vault, err := NewOPVault(fixturePath)
+	if err != nil {
+		t.Fatalf("Error creating opvault from fixture: %v", err)
+	}
+
+	if len(vault.bands) != 4 {
+		t.Errorf("Got wrong number of bands: %d", len(vault.bands))
+	}
+
+	length := vault.Length()
+	if length != 4 {
+		t.Errorf("Got wrong size: %d", length)
+	}
+}
+
+func TestGetItem(t *testing.T) {
+	vault, err := NewOPVault(fixturePath)
+	if err != nil {
+		t.Fatalf("Error creating opvault from fixture: %v", err)
+	}
+
+	tests := []struct {
+		uuid     string
+		band     string
+		category string
+		trashed  bool
+	}{
+		{uuid: "0A6E4B7E2F8D4C1B9E3A5F7D2C8B1E40", band: "0", category: "001"},
+		{uuid: "5F1C2D3E4B5A69788796A5B4C3D2E1F0", band: "5", category: "003"},
+		{uuid: "A1B2C3D4E5F60718293A4B5C6D7E8F90", band: "A", category: "005"},
+		{uuid: "F0E1D2C3B4A5968778695A4B3C2D1E0F", band: "F", category: "001", trashed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uuid, func(t *testing.T) {
+			if _, ok := vault.bands[tt.band][tt.uuid]; !ok {
+				t.Errorf("Item not loaded into band %s", tt.band)
+			}
+
+			item, err := vault.GetItem(tt.uuid)
+			if err != nil {
+				t.Fatalf("GetItem() error = %v", err)
+			}
+			if item.UUID != tt.uuid || item.Category != tt.category || item.Trashed != tt.trashed {
+				t.Errorf("GetItem() = %+v", item)
+			}
+		})
+	}
+
+	item, err := vault.GetItem("a1b2c3d4e5f60718293a4b5c6d7e8f90")
+	if err != nil {
+		t.Fatalf("GetItem() of lower case uuid error = %v", err)
+	}
+	if item.UUID != "A1B2C3D4E5F60718293A4B5C6D7E8F90" {
+		t.Errorf("GetItem() of lower case uuid = %+v", item)
+	}
+
+	_, err = vault.GetItem("B0000000000000000000000000000000")
+	if err == nil {
+		t.Errorf("GetItem() of nonexistent item did not fail")
+	}
+
+	_, err = vault.GetItem("not hex")
+	if err == nil {
+		t.Errorf("GetItem() of invalid uuid did not fail")
+	}
+}
+
+func TestLoadBands_MisfiledItem(t *testing.T) {
+	vaultPath := t.TempDir()
+	profileDir := path.Join(vaultPath, "default")
+	err := os.MkdirAll(profileDir, 0755)
+	if err != nil {
+		t.Fatalf("Failed to create profile dir: %v", err)
+	}
+
+	// an item whose uuid starts with 5 doesn't belong in band 0
+	err = ioutil.WriteFile(path.Join(profileDir, "band_0.js"), []byte(`ld({"5F1C2D3E4B5A69788796A5B4C3D2E1F0":{"uuid":"5F1C2D3E4B5A69788796A5B4C3D2E1F0"}});`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write band: %v", err)
+	}
+
+	_, err = NewOPVault(vaultPath)
+	if err == nil {
+		t.Errorf("NewOPVault() with misfiled item did not fail")
+	}
+}
+
+func TestIsOPVault(t *testing.T) {
+	tests := map[string]bool{
+		fixturePath: true,
+		"../testdata/agilekeychain/example1/1Password.agilekeychain": false,
+		"/nonexist4329489erjgar": false,
+	}
+	for vaultPath, want := range tests {
+		if got := IsOPVault(vaultPath); got != want {
+			t.Errorf("IsOPVault(%s) = %v, want %v", vaultPath, got, want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package opvault
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// profile is the contents of profile.js
+type profile struct {
+	UUID         string
+	ProfileName  string
+	PasswordHint string
+	Salt         string
+	Iterations   int
+	MasterKey    string
+	OverviewKey  string
+	CreatedAt    int64
+	UpdatedAt    int64
+}
+
+// load profile.js, which is a javascript assignment: var profile={...};
+func (v *OPVault) loadProfile() error {
+	data, err := ioutil.ReadFile(path.Join(v.baseDir, "default", "profile.js"))
+	if err != nil {
+		return err
+	}
+
+	data = bytes.TrimSpace(data)
+	data = bytes.TrimPrefix(data, []byte("var profile="))
+	data = bytes.TrimSuffix(data, []byte(";"))
+
+	err = json.Unmarshal(data, &v.profile)
+	if err != nil {
+		return fmt.Errorf("Failed to parse profile: %v", err)
+	}
+	return nil
+}
+
+// Unlock derives the key-encrypting keys from password with
+// PBKDF2-HMAC-SHA512 and uses them to decrypt and verify the profile's
+// master and overview keys.  A wrong password fails the HMAC check on the
+// master key and returns ErrAuthenticationFailed.
+func (v *OPVault) Unlock(password string) error {
+	salt, err := decodeBase64(v.profile.Salt)
+	if err != nil {
+		return err
+	}
+
+	masterBlob, err := decodeBase64(v.profile.MasterKey)
+	if err != nil {
+		return err
+	}
+
+	overviewBlob, err := decodeBase64(v.profile.OverviewKey)
+	if err != nil {
+		return err
+	}
+
+	derived, err := splitKeyPair(pbkdf2.Key([]byte(password), salt, v.profile.Iterations, 64, sha512.New))
+	if err != nil {
+		return err
+	}
+
+	master, err := decryptProfileKey(masterBlob, derived)
+	if err != nil {
+		return fmt.Errorf("Failed to decrypt master key: %w", err)
+	}
+
+	overview, err := decryptProfileKey(overviewBlob, derived)
+	if err != nil {
+		return fmt.Errorf("Failed to decrypt overview key: %w", err)
+	}
+
+	v.masterKeys = &master
+	v.overviewKeys = &overview
+	return nil
+}
+
+// Unlocked reports whether the vault's keys have been decrypted
+func (v *OPVault) Unlocked() bool {
+	return v.masterKeys != nil && v.overviewKeys != nil
+}
+
+// Lock discards the decrypted keys
+func (v *OPVault) Lock() {
+	v.masterKeys = nil
+	v.overviewKeys = nil
+}
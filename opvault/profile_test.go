@@ -0,0 +1,75 @@
+package opvault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnlock(t *testing.T) {
+	vault, err := NewOPVault(fixturePath)
+	if err != nil {
+		t.Fatalf("Error creating opvault from fixture: %v", err)
+	}
+
+	if vault.Unlocked() {
+		t.Errorf("New vault is already unlocked")
+	}
+
+	err = vault.Unlock("wrong password")
+	if !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("Unlock() with wrong password error = %v, want ErrAuthenticationFailed", err)
+	}
+	if vault.Unlocked() {
+		t.Errorf("Vault unlocked with wrong password")
+	}
+
+	err = vault.Unlock("opvault")
+	if err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+	if !vault.Unlocked() {
+		t.Errorf("Vault not unlocked after Unlock()")
+	}
+
+	vault.Lock()
+	if vault.Unlocked() {
+		t.Errorf("Vault still unlocked after Lock()")
+	}
+}
+
+func TestDecryptOpdata_Tampered(t *testing.T) {
+	vault, err := NewOPVault(fixturePath)
+	if err != nil {
+		t.Fatalf("Error creating opvault from fixture: %v", err)
+	}
+
+	err = vault.Unlock("opvault")
+	if err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+
+	// change a character in the ciphertext of a known-good blob
+	replacement := "A"
+	if vault.profile.OverviewKey[40] == 'A' {
+		replacement = "B"
+	}
+	vault.profile.OverviewKey = vault.profile.OverviewKey[:40] + replacement + vault.profile.OverviewKey[41:]
+
+	err = vault.Unlock("opvault")
+	if !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("Unlock() with tampered overview key error = %v, want ErrAuthenticationFailed", err)
+	}
+}
+
+func TestDecodeBase64(t *testing.T) {
+	for _, input := range []string{"aGVsbG8=", "aGVsbG8=\u0000", "aGVsbG8=\u0000\u0000", "aGVsbG8", "aGVsbG8\u0000\u0000\u0000"} {
+		got, err := decodeBase64(input)
+		if err != nil || string(got) != "hello" {
+			t.Errorf("decodeBase64(%q) = %q, %v, want \"hello\"", input, got, err)
+		}
+	}
+
+	if _, err := decodeBase64("aGVs\u0000bG8="); err == nil {
+		t.Error("decodeBase64() with a NUL in the middle error = nil, want error")
+	}
+}
@@ -0,0 +1 @@
+{"SL3":"C6EA4955FD224185BD2A4579C89CA9D3","list":[{"data":"U2FsdGVkX1+iBMR0Wkx9vjqxHf+qmJu96qMQJ2bSWK5izQfodbSh9pWh4JO4idfI+1HaeV610pKmqU9m01PRGv3phOCgIdVDFkg7wzwT5VpvXBeMps7QOF7kpgxDHPcPObxrQvLaWfalk/6tKVl/HbBvMWEoMpbh1iDGOWSHxeOhslQUlx4nRCPj6n8rxPpaR8UgpFL13u889ChKXIqcFPU/GvGpgsyscMBy+2jDPkvJjZXkeDYQKCzRl2k9BZ556yzSYBKnSAmxUOel7JFR9WHQvJT5u404gXnPt3sMCnF0d5AdXP8vRTQUNbTATojLnGbNjqynPoVAzpYxFWy1vhWJtn7eNfTjSn5AVg8rMeOHJKf993cZTL12nZvzG3aJmLDR0x53LVVYeLDXkaEbAeY0Vib4AOCOGjAAzbAnWFtR0l58++PJg5SfxF0WGIan4eYCn6kFkjut2qv/1AniLKQdTD7WRHXxcjouXokswh+5y0ROXjuWgl/G+Llq2W6nwgrzUhj1j2jneblIt6EbzU1bW+FlFJlamJjw3qTQ7x/iKuI3RHBOtgIQeCpr+dgmP4VoKhIxGCUivefxwLMBAYjGcuqnPC7L1aWBLcTZmHxZHjodVORleypa3AvjjADGvnIagSq3yz+P6nvTCPrkuoYsitW0RfnPP52Fyrg2AQFkq7bs36HAIGsrw77XygN+fx4qhMAcciEFZr8LtouoMXAPfVlHY/cnYXEWAh9ehny5BSoK+ar+7zPxcqQV7NvbIouGzD0S0C218Ju/zd65zLwcEsygQUcoNTfGQ7tSAxTJybGTcmjv/Q2U5nkIkWYsgm1ZweDnQnNKLMTnwfRpLeu+aQLCSxYJyXWsp3GlitWMY3gCHvNCfEWvD1kWSyac08xF1k0iCHxP0JUcSLbt0XYWn6MjioGnXhof2qMQHcoiujmpwkFxfERuoufcXGpYMBU3/jFZ7zNsQ6/QqFpZwwk2jnqD2gbYUmCxrTn1rSpYiaEatx/UKNp6BbCPEOzJ0Wt4jEwG8QyWg2nAgLdkFB0avY50vtF+TniwMgJgo0hYpy+s+DC+0Q0Y6V16nAXuyR0OGUmRfUgx5ypSkjzVPgmC3qSVZo5YGUYXqXCFRePgq+pkO5LnWefQrPuDEEen4P9zJtYCB4LEK5URTGmJB8/hMYXx30bQjf0z7SQh/LiCTuzsxGCwOJ4C3aOQ9ovsGYqNB+7t298mGXBoxWx65SlN4koOMkjtz2Iswi07mVjo/aldkPdevlhv/a6HNtCVQIlnUIV2sD2tSvdIq2+RKGmbqeUGAAENMVbdvz9lkSf9DXHkmBnft9WsMDmRHEu6T+mYx1uMvcx0ApFk+WnFaDPKB6vjyPkXunaoRv7TGF/MwGoGBAcs4vqxBUFhK0eg\u0000","validation":"U2FsdGVkX197gT0HrdseT/Zh/dd20K+qVM/QcEe8OQZmTY2Q3gLApzjIo3mhyGp/3x7XpEYSaVocJUEtIpAEPDmX1dfbusyTeh1md4z+GzDEHxxbfEOCUP4+pt/WEhVziTbH6W77Rel29+H3Zo0zRbsF8HkfwOaRXoV6BrTJ1vakfX0GVE4LkJHqwshm44GwkEJzlY0twbSHeKYb7z912cxhjLUZe22kTNeTOD60I4W0h6l5D9rpzrUXEzvLtoXb7471NJaTQeH7boiOwwwcASAUKi2lwdRE72mjm9m6a4wnCEC2URL/+gvrxxYZXgQ0te3Ccnp8rpb0tE5Mkiaqo8IVRazeh39sfviv2zx8HA0W72cXc/r/8axWYWQ1hgsrOQGoCA2qio5ZslUdE2waukte5u7AXMxqPVjVXA0ZhpHpemwAPD03bslezBvMqYcoaBN8LDBgUa/9l2T+KvvvbwNsr30K82AYGP07kmZzNI59GOLqCmGORtrccTBhEN23ke5mSwQo0jD/IIk0N2vsAc7877DcT61gan5P3nn42AcubRbnZAD5VHcgzVaoaP9fymamiHvWBo1L1flGoejJoltpPU3dQN+Y6ZXJ+lJ2MLM6BuUBSPHy2iKAixnEo0zGhaQ5NT05XB2jDrQDhtwIOd7L8Pk1/ytfl6lf77iE/TVvyVtOz4Uo+XVsBevGHBTOwC0ORo5uexUFyFiXYx8nP3uscNgnkAvYnz1O01wsMrDfzq6AVQOPWCE/6mf3aKdAa1DIEbrrNoltMOsoWMiDCcCW6MQsGlUIT1k9XXneNLNgS4U+r8dHnvc7RkQHFuD3glt96QvA3UnJLqTqnAA3kbc7h4pGElWTCZZ9H4KoCvuueSnKRiPfbgGm2zRIVS6kkCc3beB75XZr0qTkAe6gKMUmcihOsCVIMsjgj2NG3iOBI2AtN1ibtaQngF5HET4emZdCbTilol0+/B0oZEkih4BivAwNZKhW01T8oTM2iQnNsjmw1efx/ciwLao2JB4LUFyAE057Ywm3SeLbDNDhAX9aI+ohrKyH3AuIMg8zJYDDVlCnm4Lt+JahtbD7URnBhwDMPufY1zCxoMh4z7AMJVzgdmBWHctsbGVeu2U0EINk6NiXs83nhCIfMZLBFhqLX8AirSyrlUeBWfaBRz1+9s9nXi2f4ATT5288Z+hCmiDn78RTtBmcANuO2DfepyKrzk7on1AkTIxWXFP4m8XO2tzzDnZxm0PRTVwd2I/i0bpJTQF8WhrZp3c+r4R+Clvek8yzatbYMMi84f1czWtsgsUq/cb4aE6pPprSDxCpuk/vI4vWPHYXsgC1NYylRag2gQiwLcT41D+/6zWJqd8ymHZ6PJr9EipIIr/qgMoJkVqXKwx0US6CSArylWE+EE5A\u0000","level":"SL3","identifier":"C6EA4955FD224185BD2A4579C89CA9D3","iterations":10000},{"data":"U2FsdGVkX18h5R48M19CIBfndHvnOHOQncMnmVHSuG/4YwgBC7ALRVEte7X4M+O6yk4gKNnJsK0i4PVha54ELOdv+W/v9glVSQEsIvtzaUnHhTRl/4AeNvCQ+nG6pD3nVa0Zt07tQQMZ8EyJ54sCQLeTGDGn+9Bsw6JBmlj/Hr1Yeyt3sUtusIfnPtz33BUR5xh53DkuhZc3WhG9vcp3q3ZG1MvrHK3O1Ul6OLk6jV1zZ4mq0a+93vm5Y4MT78EQlSbsOU7V6l/tVjtzpQDpnLTpbs8M3jkrfSMBAW+OsZRoxtyLRVmxvwHw/GxbkfjZdqA6JwSRue6XyNS5Ha2X54zR4pXu6oVfc9NIO8LcpJAh7/jF/9/n2F5gdUI73e8h4R6NJ1ESRigfamkxpFVELUOa59codIXY3lM7X4fxHz1RqVhJlq3AMUq3hiKbbpE1AWqDH+oQz6xM8iogcALtjBsNzUWZv+CsKD2kSohoxckKFPPmIc2QlotiYSuQo1VsoVVry5kQKlxDaKGdVIFMHEpNR45+T7BdJsxvPSJ/pCfO0l69o6gQi6D4VvVpVTcgnHlvuuR7ji1MDwklUAYgwBT0SiJ+pmmjwCFUI3jiXqI2PTOF9/K9Ktc4BIYwJb1ZXPXIGnHrsc7esxt0PxP52NQflSfhb2w+CyyUh4vJZ0dlEghyUGQemO2fERJY4vq2VWWwXW7z+YSa0llnzCuj0p61qYzYZA2ElyGD5HNd9mzPqbbC+Q3CmzMrxOClFGtsnNgGh1pqEwvW79MUTX04XA1wHzIi8QoSZJNpI2/LUo8H2OO2ScpOU/tpFmLKTtS4+2hEmVMs/tOiFxjWI2ErW+sNhTknj0fHq9DTPHgLGuYOQZfbwkZEfUsX7LJmR3bTa5R1Z/+ZqyURSQuH3a5jOk25a+QqYU8Aj+J1CT3E6qlPWqW4YhHaI7o84e7HhAv0iyFDfRNsV7iXQQ4WmKiVhsNPrVksWdlkNNlbo7BQ5sQChs+OuLmMqGRRjYbOJPZhfVWbjdN84OUGi/u+plvuimOQizQAKOExeMgTY0TlO/2ayOpTcraHNtSUDCPQ9E9qH7bKgBIXGmrI7YlVBLMqORFotru+UNLNmgeCNoL1F6fVfu5Vcv/2mTHhDfHPlSkoNc75WKnZ5SyJu7yRH2+kmfYSGX+MdmGu5xtn2kUgMFzwUcAHLdsYQy8BKYotW32s/TJIiQCs3CQ9jlxQdAfDfx1DvLd4DD5MxV/FqQ0oKMe5GwtSbYa8LsfqAgybmte8QjSNQ+1PVBv/aCgWtrsmloSdhOU/Ql4SIH+dHaEwICkcdJnlVkR/9Otk5Pc+jiDKKOXL8jrlqHJ3rjwdFuLDT91x5UsLytNKRq4zlnun77AvnsVV13eK9nC4IcOo8PaH\u0000","validation":"U2FsdGVkX19HXGO39LnGl9Ss//XtqN3xLLxbBvwT6hLZStTQoA/kWoczsewCHWExYyJvl0p+3BfIFxBGkRqcIcctcjwxKJQLGwe9YjSYgCm010odWbGgZDHLt+4D6krqqLL+3bMBQ8aGnUTTk/is6aL0Nk6lkCDgTRx0bbP5+ap/RyGqTVl46diBWzsIYe2f79Ek35VBkgyWnTHE8hFrViiysReirF8askd7n0jg0cLYZnoJLluSX/NsutrLJzj3nNInAzRw91GrL6OLhUNXwZJhusM3jcMCzzFt3x6MB9Eubqn/hsQ6KLHi2LN5nOJikfAe65M0caoxz6TVrUF2uKsjT9XMs7eDCLuBKoLrWqtbRjvniUXEtWoI0yo8k/Hh5ztXFDwTVpSD+EL6ZsaJX/ctjNqb/ceSNdkcnJAu+Xd8HWownyBb4KTmXq3wSPy7YdoZPNo2kJea9FVREslgTdVPRquD0jXRWvjiPnuVujgn1ZQ2kFoAw4bsGHyQOGZW+DpjgtLWFsEaPsZI4mqJAtQxvuOyxb7bEVC/B3XY+iWJx36ZDokWwNymHMTOMXvocVu2puPqAxKUjsxRVTJqJUu2o2Ed56+K7dM3e7MhOnnC92s1mmqSrRHBgcsoPhbhI4vtUPv5qesDVv+dQ1UAYlGCzaE3rYPH1D3eu5aWj4d7uxHZZJnrDcJpatGW9cGr19gr3pWi7Dy/Xeg2EGEUsMZUyVVXvkzbqNLvUlw0n9gZSpWpTBa+RK7O/GCUqeuOFb0P0PhIIgyFerlbgh+a2u4smFNAUbiNlE/d/LGCGythYhOtaURaSGfIKx0VJqnq7tN75fcKy193H3pD4B6u+dr666gEvNaxsnrXPTIdSH8C6suM8K/6HkMMMkI/0L6H7r5SCZDJUZC1r677z/8CxnV7KtqURKyRn+P44YVVCBO94/G2i+874rd1yNoQDpD0XHFcrU7ZPFbcXj8hyFdEVn9zprYDy1xF6CqAd4Q15O73PzxZJ6l3ZxVFvp5uWr2wv9WJaOjRx9rK4ob9NDoPWxc9AJgMqCHboGMgLiNSF3WL6R6xZFOn5Vl6yK12vJy0ILarq1iKOstW2ju0DnomdkvmEfA8TlHjFmAGKZGZXZdy0DcrL/bqubPd82xh3Me7FJjwgVc61VKrJ55/MYybCPE5hek9y95b6fxen2p4K07LcfQodMVZmRns3dp7wxxaJzSdXFukuKjzYvid3q9SFQt+mtcaT4ZhrSECKWG8j9T+Yjdfs/uHM8lIO3sSH6KgROMZjWEw+QMGm9D/86AaDsTWXWIY/CN9vTKSsq2qNj8/mxFZTDYMm3ErRddMY9+VpBB7ekj8dIvOXiL3qiruwBprq3F2swdIAZ106o3Yd/26LrHUWJ5tAacoZddKl8+1\u0000","level":"SL5","identifier":"91F7E2D5E3E54447819ABDD84CFB27A2","iterations":10000}],"SL5":"91F7E2D5E3E54447819ABDD84CFB27A2"}
\ No newline at end of file
@@ -0,0 +1,5 @@
+// contents of the default vault
+var contents = [
+  ["13C8E12AC8E54B1F873BAB0824E521BC","webforms.WebForm","Hulu","hulu.com",1362350139,"",0,"N"],
+  ["5ADFF73C09004C448D45565BC4750DE2","webforms.WebForm","Tumblr","tumblr.com",1362350139,"",0,"N"]
+];
@@ -0,0 +1 @@
+[["B64B64B64B64B64B64B64B64B64B6401","passwords.Password","Base64 Payload","",1600000000,"",0,"N"],["RAWRAWRAWRAWRAWRAWRAWRAWRAWRAW01","passwords.Password","Raw Payload","",1600000000,"",0,"N"]]
\ No newline at end of file
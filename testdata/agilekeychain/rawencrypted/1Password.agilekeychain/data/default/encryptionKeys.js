@@ -0,0 +1 @@
+{"SL3":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","SL5":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","list":[{"data":"U2FsdGVkX19LqoOBfpeSYV6z374sdK/hlYbd5g1uV/e6x1+c20rxBwHp0QXUdqqGxiHEYncKDfMIsBZE6/r1iMKYdCVpdK4I6NMcie2tHF7rUutCIiPDTsWfKj4f9uCFgSbBGJ7ElZ0T4wCtiq004/k4lGfeLCj3E4mkgePNTdHu7Wn16heBURWia5vDvBgIZ8yfTMPNuPg2RQrb+yAtChMiM5ITivxvO9xA1Jg64KnpVlTL9RLQ4hnk1juVT2xf2ueno/Vr18fTbIBKQ8xcq4WyH6BDl7PY6uxpNcS5FL+64CWsAQKDj2i537vP8XU9hS7c1yveA3Y6zP/4gEBbDhlb8l01fXAdUrGo38gg1OJP1tXvR6qY2+zSRkFGWW1XOtOPZOh7neFAE/zkSqCM09Q3mkGOjPwkmITqqL3lDa63I6PVlmEKOJ0P1lX6wUHk53Kqz4MYJYpk7R16dfLqF5BWvb1I/pegLBl8A25B1wYlmyiedx/rtWJiTlKshrj93+VTDq7gRGzqXedu6AUbtWZ/wEPWvQFwHgOFT8+qeXeLFtb0OzqiHTxzmG7Apw0R6GaoRioTHc3rDADumV0ZaDppSm7SamU+6wZJlqVAKR4A8Anu/2ZvEVmsETHhYiWIhIUY6oPRtDA2tslxKj3yw9houxCD/ORL+E+DakAgMS/Ae3PDBRDUNZGcqnNy5oYY26os48ErUngEg8xBA+bkf9fOJyHSULF7wSlyM50VhJP+NeS4qgfetAo+O5Pknl8Qcy1ChTFugOxwHGK5x6RpdqQZeZNAjA4MlNHRimJ+ZiQuBKrU/XcaaUJ1yIXcHricUVw/LfOGzxQzEwi0gvA+Rm9uVhKXc4irGk1CBQClN3sM32YELY2tolBW/0IbHmlqweii8qHOil5io8w4/TMAjowVd/hhxae5VvfgtMvVIAdwTT/4vuwY3P59/f9N9j7NLAaMJNTGMTnriRqNJHrMcOcEHlxSkUzhJ6H0MMjP14s8tI6iR8yfd7d74h7KAkkmyA9EKRzsVQ5AN0s6ykGs35msZQL32/RHxcrjhtAYzEDdvPsXfXw73J7a1eZExx6nGTXn/s+9RbRoHxoBYSrQ7fVpWzb/8egLfz38tSbhEPBdWZTc6vIdcrd3yokG4qVrsTFHxlmJkjGdIDLbtTgQa/8+N78zzsdysvit+IdyOkmn31ceufttZ6ZuweuJWfv/R+FBmhChViP4teaGIKRU14K6OJpwbah1P05ps96Fl1t5zvLhpamv6UbrmwjHEcWJ8nFnn3+ka3cMMzx93m9wWnCSYrsku76TSc0OlkNx6yId/kB3MX0DG4AYO2V7Mxc6Fm+dAZtQbtquJ/nUQjlkZBiSzUXxUAC4lppkHduf1lmbhlYWKDBYjUGI9DtECFI1\u0000","validation":"U2FsdGVkX19eK5wcSW5vpEd5yRqmrF/wAE5QqEWGQaHi8ZzGpGhNLNc1YfmBGxWYB/RwLT8TN7xCkBXAvfGDMIuB4FoXmFjqUHyHZHZ8/k8Bck3fU1p9ircbXLQPfmoyq3HYCSex/YRvs/sVAAzhoIlrhwaxaknNuaBihIW+S8aL5pYWIRp2ROGYvcRiYW7zQuqlNjW+S8alKZVI4329rBEFgG3nXRRj+5K2smNFc01owhYiArc9u2U9eRr8N5d0DhamMHpEQGj8NDKhLwDX2RCmm0BW+06Ro72XySHZFdYKTo+pH49Hl80D4DVrUU2Gc1XMluo6zhMqCnFMV7XiDlhqY3rDVXirFP1G7vWmDRF0nY9IU9uOtkLI4kdoacIhijn2JigkY9Mw+xYTuA61ARdLGaKKPQE7h8davTZJhfeonpmD0q6v8GYsbTFuLIN1QIzhS8m1y0M1EJ7O2KriXHaMPhLuw3WXEJsMPu7zrj+yN7aDS7RJZqNQtTVqHTOgrwDS0TluDbDSAcgNFmME7sTaY6fts97nNk/+DfEZBHqAX1G2U+sfuQE1jqnxPPrACIjfskkqUj4JzKGQLw5JNE4uUAcFwFJVuiAOBkPTmcGlNc8bruwYm86OKpYauI3HBaCJ6ZK38hMO0YC9RnBaG7GX13TxCpTOo4pJCA495BrxPWsbDB4a8vBI4TQ3A7Xkiufo9bXqURonADrdYI9ir1lr4GR2traUcRus2XaEiSJahX//e308LNudaxjfsklsT99d2GNbgbxmPb09m8Bkf48GyyM0X0fBGf/6JLiT7zSwKCj9Km2dwPWTLpbrLruTpRupkejK9CkR+yGFfZIAQPnB3Ku0N5U5lQthEbolu4gutkn2PY3THEIqBpsVBCVOmn234U+fJzUPHq0WQgRwD9yUrl7Rc8iOAv27+UUsmTH4zuUuCO9iiSwh2f6foBzU7erHXd6kbzhmEG/jbYdn+afUm/smozYD4J1cqb42G1amSBgj34i+u+opd1Yu8PYw343FMJAWqbVNvCYcoqkWICdEc/GucwyeRhBHy79hoa/cGqp6Yo8Ne/2+TCBmlObpVpoUK748t9g83W5TDuwjhh9wVYxx9IuOGVsEEFPm6SnG5ZBBgLEKVHT3hiFKmMLXwekz86DCEo+W9AaccRHCRJPJ+44De2JlXFTo6qofI6+7RhAqKtuEh71I9QxSaLddDktw8VFmIUD2IavN6n1j4WPS5BZimmB4QypXzZFXtQsiGvfWA8r9WncpKT2ttPauZsCRrlXTXhwWfg9cwCN4/wBKOO12BwHXMfjhGtz7qNrowzhciiHitNywdGwminheF9xzdb0eae/Q/qpUdVQ7aymD/ng9LaawkFAIZYUkkRFsnHeMpx+TenuHsh+HbItu\u0000","level":"SL5","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","iterations":1000},{"data":"U2FsdGVkX19rYMziu0VlfEUjdI0VK5K2wkV8eMMfUJtlMv0JuSEf9Hmm/f46iMgvwVx+hwbkMZ4nCvMBFll8hHR/o62r1PIF1GPePjfHA7R5EgkqWZyFWlI+RF2qDNjqqevYTgb5PGGBzbPMK2Pf7slT1YyVM3GNnCMudiPPQyK+3BI0RmVujdBJjo7/ajo6bYDn9aHjH5ldCLhEiI9iM5za0kqHxuGnJvDf8Wep6f4722WJ5O9XLXVsX5wmLMmqCkBdB+vqQmnM1b+GwnjCMdGQ4TGH09A4f/HvmhXa2ZOtFMkftfDWaoNRpgGK4Xv6tyOO8SwJbwMo3ogoEu6UUWKtS7pt6gQv77asYzE2gWxQSTeQRO5TzJekS3f2JbKyBM2RDCagu87rj9hZQ/OH7iE8UjweYmmg5cTDDgzrg/GA9wD4oM5n5cYGhKonbF+jSBFGhVXXrEI9FNrpUbRZ2tizmT0rpJ6xIGwSnuKZoVZLntIF/rLjn4sNP0WTuIDm+MHb0cYAkTSaOu89xoOMHdZCFK1MpqNLHjG1W/UdpnMLsZU6q0h2uhl+eYtK4UYkJIV6lXXCfLVXuDGkgTKkb2jFwKirZcuiUWoIBxCurF/o90hSks3lwhrWtY0i+nGpFO8y8YlecojxcQjFa+Uz27eYSKWUeyjZWjVCNK2W7Q/vDTtN7d2FdyFR9glUs9YgWihK/j4z1KhyBRqDffbB/JVi7h3FimhABGJKWWlZlHxRYw8E7lwlfqRFpWGUVe9cPp/O1mbKXQf7xma5o8PWQjoeveeqew4GQk+OX4MiiDns8n6EwgtBkENDlbnezrQjhpnnj2MJBB92iZ3Ot5fwEMS13YkTw9e683ZNd9Ea8XZYgBWNvXK8wgafchw+vc9skJnepEvomf0cdLOJQzjCPh7zcCd2BIpTFzzRx6ez6FWXU+PAA9yO+MREDnIxsNrNA2B4kvdK6FME/TsONI/AvBd+eKjp+ozCDuybT9ZS8AWhrDIiuk+GcpVQy6JqY3kTUlEqzR5JHq/KhrT/GzdzEscZ81jFebBX3sosg+CStU3Zd6jKLdJH1qgHrRh+VsfyEbbS0mYMc/jSfJgX3SqvgFqla0aVfK9m6aqXeRYv3dpKJWjNEtK5WyXtIWbbVoe6MvFk2Xr/ZJbkaX2Awt6IqQr2uNVJ9GweXc89iqjz3t7Xcg9Nnwg1pAPdk3V51lWiwnA7z0Lq0N28gfDOEv//na9GubEKzsuGk5rh6AZYSxLnaUc6dOzaftgHpPePhZhnQBKmz1GBi0GbJoCExtHrG19+w1dGj/YARAYK1qYMNQxbQuEf3U3WKX0PrJ8CzfWp+2D191Ts7vnKhOwF5Hktrf8EoIs+s7xgvP7EYivP0zig85blDqmAFmU0HQX0+bBS\u0000","validation":"U2FsdGVkX19Bh0G0zywhMZwXNkOb09AQlrWxbVgaYGcllt587msAmbcfmQdiohQNdBHrTsqpTnDy+do7WZcXkedz8n3MfQ2JdtTafMKfHcmTyI1EPDMp2p8B90ASz8Jl4gZTYzhj+MDhQz8H5bistXkURe+uo4jWNGxQ173L8xfMqpb1T/ZIQeLdFHxu4B3nq0Nxd/ArGSGBMl6NsHJM2Le8p//l30wfBenrevKWeT9HjUbo+VaLklBsieZQl+Hi+nienq4HJs4MwB2rIg0oJ3hFOTdmDuesqo9rMceyJ1RCpvTb5FwP79q9XUjBGMhs5gz0MYyGscSv6PFcoyAu+GUYZjhdVC+/ExRJNY/Tkhzt0gmxMDD3JH2+diHdCbnGMrY8BlDttroj5D0NbRip8F2kdZ2y///MPcAxVFrPEWoKowubNjWropJw2hLFJ/s5z6/jJNfFBPUs3wJz4ckv9mvmOzQ1LLX1iO1PpJabXGhkfUI5nJszYqIo2UfTjY3651lWc4hQfBDrrjDo0Z7n1nbIRujDdLweL1A2eC/5aicUQwx5DERirgHrOT8WS1v3RtVlI8t++aVuIEHaczGYy+8QqoKnbFnlRjwE77fqZb88IaLaYlqLaZu7kp0+yS8jhO8qL24Sz3I0ddXlZZ+PPW1uvTebHqfkD+EriLDMdmo8Z7Y0NyEcqKeAjOBgdVMqPPjOa8wHoy2pC+4weWASEyLMGIgrCt2biAzMTpbDOiZdo5ak+//8WP8gZXXryE615KznhidamdD3445YytRh0OYc+amh/pEXsJb/9vc5sIjJYTgYBafPZxskLvvGQVtl5NVIhZh9zoDRZTKGyPHq41v51XvQYETuhpub40a5y1lhhsZqs9+84kKxJ9cRh3AgmfK9tWBBADcBik++bOjtYXV2+wNcN3gHgiTKcqluQDAWv7NwtYmtJKumzZsJGmvkp5BUNDLuvnTGpih6oKuRhB6naO78aPcUg8ENSLpcrbkr0fHjkA2i91KtbNTgNxt8cOUqY4URX+HB2FCM+qsuxXb9SCHlHBfkMWwJJLziFQdXHF9PGF6bpk4YzC63vKZMkUnAfCU4oFuC3wu/9G2WbQ5lSM07LxYmWv0QWhTcqJDcpvjCbOVOOX27y/sKmgJ+j8TI464WbI6dko2G1Y9RN26aidDyUcLLlmDcm2yVf9AG6oR/gdi/okNDFtlwjuOjFLq8uZ7PopWIMAExyxyJiXS5r3CfGR4O0AVwTqeMvhE7VUkqc12uOvTR4tNGwJNKJIDuM+8K5RJ6kMLKkvPeDNSb8eyxt05+2gFgb22V4r16xzxDdJNjOFYZ6vxFczbevc6TVHT0FM4hqE4QsCzBVO8rySNGh2PndUZXaZzRMQsYd8AR8Xgg8qvAeF8cZuRi\u0000","level":"SL3","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","iterations":1000}]}
\ No newline at end of file
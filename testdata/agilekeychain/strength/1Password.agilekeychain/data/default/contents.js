@@ -0,0 +1 @@
+[["57E4A7E057E4A7E057E4A7E057E4A700","webforms.WebForm","Unrated","unrated.example.com",1400000000,"",0,"N"],["57E4A7E057E4A7E057E4A7E057E4A742","webforms.WebForm","Weak","weak.example.com",1400000000,"",42,"N"],["57E4A7E057E4A7E057E4A7E057E4A7FF","webforms.WebForm","Strong","strong.example.com",1400000000,"",100,"N"]]
\ No newline at end of file
@@ -0,0 +1 @@
+{"SL3":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","SL5":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","list":[{"data":"U2FsdGVkX1/SPBYo3ya7rrLdS4+0GbwAzHinFvjVUeb51orc77mXAvpJZlvsNECn3GFLVlxtudFkRv2kZXZZZCZqOa266EdDYlM0rx2R4wua+FUhBVw8kEjurZu+Y1IJFv7zz3UDSH2qvkqey21XAELL45J1mFsNzhmpPIfXrmFepKnL6txtooCQ/muyr7/1yqBJ+gjOKhCRVXBUGJq3R8T80IL7FDhLY81tvUPcedvEY4CYQygdSVM5AukxNFMN4X9RfkJIHDscIvirajJOx6T2QlHI96mzAM/0Jdm8OHpt/IB6LPVxHOqTjekN2sLwXbyBaYHhgruyY8mDRJpwsV6aXhfy6gT/VlRWPaUaDtIrZpNh0non+r/nQJ6Z5rl4mvHX8roH4mxR90HzvIXtwAzjRUEUmjmeBiHrSL2fxNATp5fsuVwFuxmesX+P4lLKnOKugf0t4r5QVbFB+D4MTLolbvipAlETnFcnBodvGWi4itOzpBMNKQ0qcrWBJYvPy+lxaCk3yIagkxlhYplSmhGxyL24dhK2ALp++8k3v8hfLM9/9IKBhnOksxtB8PffRwrAFBVVaFHzHtHJFR/fAEdT3VXnBTZjxZx4P13sZ6NB6uDV7aUZ6Zuf8OkPuKaqNF9Rsyfxw0iowKYOTdfSWDUsYI9Xaz+Xk2/XPv2Mwqe2NfdAdDLsNMJZ9YyQ/itZRg6nIfCyhiq5QfZF80UNyDz4p1Q8wMCKfDxnICDWRhbcLONwXA4H7tPJmHl7dyPqO1teTPtlpGsoPmumkAFA6Tk91ezn4m8Doy4JoswzjFEMEawQ3F0/9tx9X9KOa7jfNcHp67SLQWjxCLstAEmhu8Y+Hb3pCDu0+4HGjocCgomrHF2tAV181GS2hezrXRC84ASuCPM5cPxslJcOMjuc5hxfDD+jJvvKMnYTHr2L4HAjaIm2smtpl+DbeV/z8thsSraGgcbIYcIC9Ine5JmZSYX5FUcozaJkDCNu5xseFf5yJybF6/O8WPw9hOJe1ReJlMTdmYuItFMqWI0QaWU+ye5H/D/thWHW99gB/MsRSH0Qiz7ehUM0yRcoBWSl4HpHZhQiX6IzdwFLGz4DCcd2cDv70t0Txd9iNpy+wUjADYoQw4BHWCfebiwWMfkoGEBRyE5nN7xVuDLEC3ZxGqBUygOZk3gD/nxkC3pvQu2FXYdJeu+76qBmBv06f2V0UtiooJ0a5XP81O1hRiIAf/q3XNWMX70ua9s7A4y8ICkKO4yPW6HjtBhSbmYRqu8i/0RBvbcygnfRv7+v1urOdukByJJGrd/h2rkFVxhb/wGuuTj+qOhdSeG63Ma76/p0oqBqSDEcXqW7BFXgQw9XinkZsbDyUePDDv23CHC9ws/ihKdQRuajLBsvw1t1j7Ilxfbn\u0000","validation":"U2FsdGVkX18xajgjtUD7DoQ/yrQxb9hkB3p+UxGCHySXbN/UFenAcDU8lrumPBhiPw2+jtSc30Ni3gCn4zhlv80Wqx6Ed59z18T8QCCUA/AaPk3fOANRpYpKSVj4O2G7uA0WyR8zpOiYyS8jLVXEhKGO7DwWiDBwlE3+bor02BBuVz041Zb1YtzIlh5BPU41s3mcn9ZwFo/iZdNnoy+S4W0IiQpfdkqw4a9+0uEcdN+KEa2Q/AZd7DXNGcI4EDkAwFkIqGDlXHA4DkVja49MSjAlOwJGjor46YXfxi7NxHVqTDhtcALEiPfJ2ULKYZ+cdFczdT1QVFKcxWVb0DdS69Fv41qsVI8H+Tc7vMyIs0K0MoKNuCkvHksB1ytSS8uaxI8S9wIekBmyeSL5dMRigHZKWP3m98erVbE/+xeAJ/mLc7YR40parKfNRf8x3qs3o0BuqvGMsLo2QrIzFxnl4znpnq3sEmBGDDoKXDoIVYLaUNM/DYbPc5xdUhtsZJ9JfZ+DLnTwdqRxpLIlfUfa9boCzBe5/onA11WaxKGEMF+XpBzj/5PSN7wap3eSxavT+hPdu8hn6caBE9FchOVeqgCBoxcR9s+/u8uT4LebDrYcf3yruzuAgVrrysxIxjBw1RCu4G3tvKefZO0XG2k5PUC8H4xS9L3GAX3ZmD5cIPlbDP6DO+wi89QSb+q0RjIkAV9mUpGc/ZDl5zsbSPsRyU5ZzM/wnHHALZkntE3D5Fb166g/H/aJqHFMb4159VuRcWPYM9Cq44C3X9HpzYBljt5nLs5+DK6LQZyGEqH7tx8YEW4yeuYOq6pSvnXrUHU65fvhP6qBCC57y2t8EKVG1pDGp3AU/mErKQ+jdNqHEdbxBCN6OeVSigVNWFt6rP/J6yyTryWzQONVfaYXfLNh9NV2jPhjR4izYf4TOWJfezwG9dDGwLu6UvohztuCmg016ozQMB2poX0lbVLrv19/2R59fwHDdGBpfyRfq3WFTtwup9PErTn0hiiDe/7yc5/ugcMwmBr2/IiWKAZmOY0WGac6p26AoFdZTRARm7VEgXazR4D3G89A6gg3yM9KFP0WTfoFY7TSO4pHjbJBarYGOGkwOw/PfuUCIJ90j8Rpc2s2Brx9DynFhYLJelRcOTg84L9Z5XKmp6ImAPbz4Q9aWpbDIwo+Filfz8wMqSgqG0QYjzfTO0KKA0faXMS9RD32ogUsakxzYz0MBJHJgae8krD7AvJkC/OKRMcuMTGaQYMXMm9MAlRgDjpWW5y1oQ9ZOIPW50+IDzRchOmucvB799hXP0mFHABLrkV2uAC3njw16x8IqL7AOO13aLDD8aHeMNgPJUZhSjECvLDwQI3ko49F6g149BKpiMpmrR0uyzYoT3d3696dr93xdLHMTVJu\u0000","level":"SL5","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","iterations":1000},{"data":"U2FsdGVkX191B6Pq9bJP87bB1pAri5qBPguXzBrJDPuVwEYImph2EEV+AeR+6GwVHPA+FtRT9VcNKH/DUlTACtjj/pKFVNeUeN9sNk+smZ3Prz7NcbfYVdXuPAWeLvuVO/XflmU/AseZyH2ikDSkqznZUa2CISZhlP0wXWppEDTrAXb1ELwghzYe6pjQgfeJN7XcvLF5tiY1/HPZG7/mcJJgywj25i6S3f0T47wJHxjFjnRJLa1QyziTkRt++YMuA5XAcduSaX9phg6C5xeohq40hg+vfpw1ZcORPWBjUVYRQvqBFjitoXW9sqFA+UqP5K1mrO3nO7rL4MfJaINgsFgxsJYkDc4NCPfTDOGyGZUs08dQtLea8mq8UJfa9b4ANRRn85Zromfk0TOqTTWMBnKed8/mR5sRCq0p7lhsxG9l75t5rQVERu3p/Q6lociRyv8sr5n/uVvDwxtaPiceNJzI5c8mB9nkIIyDmb/Hrs42jtQlD3fTQt5bhfLfKr7yG2SibvOw10FZxnjjpJ44aFNoLW9RW2RMtMis3erWNWyMg0uTunBX4HlpN0ALHPVGIP6C2cU1y6xeK7B/Gls4JD+WfwHd1tIHXjWQ+YjugBbtOLKKGno4mfx/O4kzEzg7t909fScpKQi6E3hzVGARs/8VZLJnP4aDyEC48v5/EOWDXTuw7fMTX2CFashVBoHIwQL1VoqRMV6CUTVZ8unJyxdG3Sfc0GeeFF7b5AKT7AL9lkbtZKlkspAdPmRDADL+5J/PHelKL7F22N1rfa12KNHm7nOniPf/gWzskQKyarrudFMgfBgQityR7boCFwnKuHgmuTPpkUguezbxQq1UnsIpz0PCUcmbTBHw6C1509jWRLXMS1qhlmRsPVQHTxi5A0bOILsM+t2JEUbh/r7pniPo7YCp4dGk/Xht8am/3WNSK0bLOCpsDx7Sex830/A7UIeECk5ci8nwP/VEbCb7EcErQBmNV+5hhuFfRBS4JBkikQw2JHjs3K8NNdw+3XcuELDuqq37NMq3kCLKjXhSipy3snyoAm6hD10JHS7rnZEX63/T+ezVTnQymNZCaJXwnlGhyVPwqUGXzORafXv3ikAp78d3sbJDQA03S8p1KHo/5K14xEvZJNos45UvkOBIKyyHB4J2/IR2101A1cb7gODQgHyNG4FMFFpngDqUNsZQWiqvJFcIaqIbXXk5vRazanPa2CBJQoT8VLUMliVThZ/hPMqFf2T7PWrT64yryJJ31oeColQ2o9FGMJuVPSk2W8BSnrrJ5TTArrlUBFw3lC4KD7C0LFe1jBi83rLoLTWoT6DXbI3LBvPBAdZFWBB63kUNbZdw1uqZRRCO0dgw2D95/Qpq/hb/zerIRpyHzzIh4YrYZH5/eHFi3TssqRUJ\u0000","validation":"U2FsdGVkX1+4jexPr9Nh+QTgSCV6JliDib2kae36Tcdum4IqxRB0KLP+8G4uX9/aWTwDov51rYmKwGgxFzIn4OGYef8yyx4xOFPxoJ6ntxBx6pi7pskW0yijdT3fTKuC6oGR0AS6va+e7/6HDo40dLNhwKLpycJD2Bx5/ouLBo43QOuxt4yaAvTypAO0oBJKdb1qsAa8E0Fg5y+mM3HyFqKi4+C2UvCA9ITq5VBu0Om8JN0nx+SmHkaZstVc6nV+S+vmqWJiuXnLAWr2LVedDxUC4EqcB0znnEWrUH7sBf8rxk/p5ojAStRS4ErJTTVnpzVl4wJwZM+SMu3g7PSrEK2m6L2OcavIPscknpnzTIZO2ixnb2C5DE6H+zoHoMVPkksICCmb/5zMd7e8F8jPtAYuHTfexfs8mtMMzyLiiG8irljO9EY3Dk+7YN0aegNyLJ/vUO8MfWbC0Wf/gE9ruqZzWwEeQoYGyQjc1xHMf5hNy25p9Nmj2bvHtugl2eEZmAL2g/lokogi9aw4KprHUs7OQ7lPlywHnb1dc2jGnfwaxtB5Zx30tcEmwD5piEgDy7xkcIpBbYHlqGrcL3ZyFbcetygmObczScuGI7zd41Xpb5KE6rmxGqjdW+g/O4G7i7pom8IMegIdQOs9nR1AMeLt/J7k0C8xMnwMqcOLJ42UN9SGX9HDTFZJ2fNff1eEnZSweA3dz69TtArBFxCYQC1YDZM3r2lsh9KtOj8clnH3dBgdw0h2UVCRrUg+ar2cM8IpV+DARcZv0o3JTucTPtmRkz6iRviW3EqAA+igEU1GjJihlYcLh1CYPs9K5gLilTUMOPjM2ktZESerbu/4qC/OPajz8XReX4twLCPnUeBi2RPiIpDuaafvfkDHPDCmEHR9VUre9kzCEkYJRmUweg9/ueI23jg1AhL+Pry8RiNx3+E6BXiq4xzIfYoD/FSTClEYkKmiGv0HWHUxSfT9pWffQEA/YHdWx1220y+5o4PEisw3gYUV37KvnGiQnoKWcS+C6sDKSxy6xVXhPqAPSOe979G5isgBKcddwNR4T8Ho06qEzEDpJMgtY1PQCFOdg2cwmFXZUWsgYpgwSBl/PxN8r5qgYJIfQhSKv/A74gNfbbNiHJX93xsK1jiBIc/cu3Op+UkUlZLFSHf3Eo1gkGIGyz/0lg3TltEOOOfIfCVCu3/oJ7oAf1qoSaRrLnyX2jiRrEAHWnCQCI/YVemFBIsCMte91yhEXegbHBnXAgc4EWtpqlLycMcGURxRu83SCiqcHesUIG/ZznHeL116Ztw6S1AuhL3U6ip2Nt6s7rjxUq7itbnlTMy6+1Px6tmzOeGzXo0atMq2OBiApkrePOxsixSe1s69g5OOolAgBFsIKgR87NQaeUhQGK7BbmeO\u0000","level":"SL3","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","iterations":1000}]}
\ No newline at end of file
@@ -0,0 +1 @@
+[["C0FFEE00C0FFEE00C0FFEE00C0FFEE01","webforms.WebForm","Café","cafe.example.com",1600000000,"",0,"N"]]
\ No newline at end of file
@@ -0,0 +1 @@
+{"SL3":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","SL5":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","list":[{"data":"U2FsdGVkX18A7ZSHZkB07bYeJSpdCvk25hsyTMX7SVYSqSQhAIw+HT1jPvffKUTAO55b2nABxSqU6U1JfAVcLo8IuokmipsBOFLcAvpeLjgB6vREXp+3MWUSoaN43Iom3+E2XknigFVUxGkncGZy02wbqh05xtUf1IWgD3ZU6i7FfLOANgWXmIqkFcHhf675Nv3QfW694uo7xoXewvaUQ4KeBRTm1q+6C3ZnnnaCWP2zo5tEbTOKhzpjgfZR/4Mo7R2Q9a4Yi29DAU+8W2h/KWySROAVneTeWgWPx0LYkS0BC9e+M01lqW9pt2BYmr7wjosfYCach5ejUhwHVibqI4iJBC0wsqknUNREjWkCBvzMjrM5fHKHZl0Ij5/12pMuK8KXAXXGnSmbUIx0Q/mkPv3U85/BKGnRoyU3rfXmcsHjJp0YXmQ248ygb9/Vs/7LdFJ9Jncn9c0bdrfWpreTFkf1+3xpuPgafYkfFXz7U4qJwmRWdLbX4TK0y3wjaCVstNYdkJavgY00MKmM9VJkYdrmV5jls6TVGzjah8wl213I/n+JKzof25jWHIxqHEDBROvLfHDipHRHMFE+weUQqkdFy2pwBGYH0WThX2gkfN5H3XLKiCJ1Ar/uKLXKxtkHj+2F6X33FSnDOK5fGdDaPLOmJfAsQgdYvOfHcHT+iCfvEOzztQuzNHQIyrEjv3YExjP12Es7Oc9M/WeOkgzdqIkqwxwYVr4v7f9Fbr5qqJJP0GXPGAUFiGq/yKOTYFDwpXOm05rbh1pPt0jpmfoNZd8Ga9WJxu5g4Q520kU25jtrImp7S8O+crWyi4q7ebt1PACafK2h4QlNdjezxC3J3RCDBGi7BHdnz1cPmXk/965b41ds5cX02BktlI6yuSVjrJEk7D4UNxeFXS5kF8XWnRo5JBW5rDyI+ibAnukiwvmzBOhmJnE72Nir2U2NjV143XIAcfkx75yN3ZgwUpNkbtTu++5OCQFWt+CYePDfJtNomXCaN8AlIPrnd+XR7P3kZmK4KLo2xMZiFLEXPokWzk2MFYnuRpcK4PRjhTtIT/2jLPZgbP+X2m3e19hXbbOglpodJcHqFLyA5LYCLbPP7KWj5DJEMQnT1o3GgYJTqncqRa+or1GBHGyPhLTY83gSigpawhK6o6M09tjAoJbOrTmBoP+0/h80vs2BhYfDJsGF0f9cg8XUOrRTQGHjcMJfh9txkAPT03QPBRfJbAskBIP3+kklGQvDZRd8wb9DmkMuI+1Uwb0e5skqJpIfH9ThAL+fJiXxJfP4+irZ5DlEohUiaD18KVpacAmvTAQHV6t91bsD6lReXZVnj5RD/xUxgQZDDIzGaJc6HyUgQ7KCd8N7D6FVzUZzRl7nBzODux3G/7mO8u3o+mlMoqKxdCqa\u0000","validation":"U2FsdGVkX19xjnqles/y1+Tx0TCi3E0N9DW4e7R4FurAw2dJgukpl/vZQxm7k26M+LFWF7kdJAV2cJ+umXbQgm2e2t1RwRBYfOmyl2JP78+3AJ8E0BpidldLLDEgs1Rdg0Zyaczf7dCnvMXf7H6JWbaVA+Qf6I+J9wbZ4ZW/pipIUBgtCFn0alsglGsx60JGmaVoKVvyFH9RmIMfiIa6WoWvRa76h4uU+Faf3Aq24CgbwVbMjtJUNH3VPNHQYB5AAqhO7yv9eSTdAWCgOjtCizt+6FgfE5LCTVwD1lkdygtJ25h0YTyXjszf1BwTiChnjL0Rt9YE9NguF9RzI/o8MyLnxr0S1+aHudFhvi9N6Vh4JfcsGciyo7m2TwdBtg+OdWt9NOpcJ0xiCdriaaA4Z1GhnyLXlb1EV5WJNaav9jLp3pV8Thk+Je8cfkJJ8RIl6A4h3saF/ZDMSkyKm6cnQqXppWQCZgcg+gdSyP3lcFYYZH3HsuB5qtGNu8kP2PjwHjsCYXiibEM8GVFFBu3kx+28Uy0bQ0Q3FoLark4yQqFc/xEC3dvCTEWUHFD4b5rD6QFxX6uNrjKcbVAIrbu2/GwqHhY8aW7NJa1o46vHgOlAFtJpYHM8h8zmOGJXGO/BOjjunsWTgmey7um+mSV97ob+mbqkLXdbpHsjh/oxb2kWgkaOSnvxkWqBmoNSwBqyrrzWe8dqEj46osdLSe6GsyQUNeJj0kgQU/wmqQ0Ph5ZIeIwTtBb+dwtc9IkJtFXOU2TT+WG6YgjJjQsXZOF8oFcuwtilcmxC+ui7ZVaZx9YU+ND1ctmzX+xKGav4ui7CFxdR3i+xZQJAsO45duvRAPRH6rzy6jqBdN2N7qfdEGr9b61ZZADusB2NEeNs8iEGTi/41D2qdv8FQCEb0E8N+Enp267JuV7caRP/ThO2wusRpQ4k/1UPunmYbHTfpH9FaQK/23NrdpB8tweJ7MM25Hb+ib1oHKOoVekokBL7OxZEU5yqUvkAnL5pnA41eCZiURsVLp4qKVjcfjsY32JND34/InRiqW1xgv07/TMiVC8WGXYBkGQ87Ud7QIr9nbvSdILc04kDkeEtQqRj/Xbl+Ybnxc/OmIN73cordrYBOyXYl/5ikBs00yj9BL1Gfu2qUZXa7FoWNWO3te/bRZxmwd89efJ8kz8JXk+XBzey9Y1+duXlTJy09NsX+F6lfAAJltpg0jqtVfSZtU6Pk5IrgDKw35phDzEhea+7IQ9SlE7h+BZVV4L35r1jtsZGXYoTXTpcAnSUyTtcOLBhxfUsD66co2YehugX43kzFnq2di23FRdWN7v0pGRPJNrxtXonOlfncmemi1OmItin0fjdovy5OCU/XF8w9uM19XSb1IV1lKb+yv0g6j31qcL4de2+\u0000","level":"SL5","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","iterations":1000},{"data":"U2FsdGVkX189jsJYgdXRie/7weoaYAjEoGhgCXOWXaf//So2KTF/uRqmcyjjKqD308dGuTJk4cBIzj+n4dQ9fupZVYvlrk6YEWI3y2cxZwlVxG1l85tlwz7A26B06p3j1mUcwuUhD48An4db6zWInEBuLDxHl8CghoPmVNuAIT/Upsg7VwXglogdQDQv0XZUshTL/H9fvzY2L2y78zCteZKXpRZ2lbdpd5eyCtD+5fVShIj30SRWYRaDiidUl5dAc8kdY7Op/ab3vzyORwq2Pcuk4QRb+ZcrpN6c9rfa1AehUBCjTf0kTqXaVo9gdxwlM0H2LAs8s7HGUZyZ7WplLzpXbKez1KsYdUFHJ+Znt4s4fMAbolYHisMFmj+V7ZvVrhVSg4i/UmYXw2s5QhSo4O9JH9fSKgbc6AMiPeSLvHUbYTkJmsPqva9vW+Nuzb5/v2w1FkEqNDLRnvkpnkWt6wSa4Mt14Fl/KWjISjshAtRy4CbsK8yU/dAldmel8HqmjI47F4DJu5IpdiyPzt40QqqXg5o8v8OS0DQy91bk5FcqH75GtyOMe8BQID9Wlg6ZoPdNnnghf7AIzIh2p3/SGOsMcSVz78XWZ9oenqdxDDNNcg2D6HgWjtDrC+hTFFPkkpll6R5i6h6OebQegcjlIQZHHBGiKjlqloiAiTu8aa3zOP6RXBKv4SPsF2Rsd0GSTv3TflEjh2u2RY851S3PAGteh5ltHEaZwDEz3NUAUwdTySMPY/CL3+3jkBl+LOJ5PIxnwpA98LbxJRUPQNNNJG7ER43Izp1aNdI0XBUzVbwUweWZvirSR3/uQMd/KSc75AvIhrEj9yLySEzHg5qhSi0gsN4qKWQYDRkO9THBil/HtQaYKFPcykME7zvCTgFg8+L9vPtFa6uAa4PGREP9kI/7Pve+wtiTi7cMJgIXvL5M9YuZebY1wyBa9GF5nBJbS2N03q+j/BnT3GXyzB09S+DcobyvzGdb5Y2HDyPLxYLroYnzWvH/4wmIEl9RKsUZ2puzcPHpzwZ1ZvdbiE/Vy14M1dXDdRxpmKM2N002uCTc9Zbr5UnYxksImQdVesO6tPusu0QegeyS+uVwNJnMqk8U27ientQRQMLnF6TMxrbe4Hj0dBeMQFufb600CYbEfW23xCLldYdq96DToukh2HpX2Zx+A5c9K8UBZd30pVZP7Hb5vAthjDNoaSH0KDsS6tNuMaHS52XtK7zwdXTMHZXw0TXcaHKBxe91BpUHO3zKPurfclxwSFHj3q0AdvyOcQ1nHfhT3ZHH1/VN0ZoUz1dwq8861s5uRinrmZmkqNCiMCVb270GPEHSccxeanEFbC9MU1q91uSmsZHU2c3SHeX7OFQ+07KyLam0t1Trk/cTexpMqaUXe5nK/8R2v+mo\u0000","validation":"U2FsdGVkX18qHtZxQCHXTbAcogyIuQfan7SxykLHtyPVpsGLis1W5tjt2Ork1pTJPHPMwuGptpP9/+hXoCjfiMF8Sz37+oMEatDPSQfvxR/R6Uybj8CdfqNKE2S8ML1EH1gJ/tbJiAV7J0hV5mbKN9/vAA0946bMSoGpqF6TbW+nEQslAIzl+bNylJjMhv/2kXhZuqiLkxoJWoCrVbgg8KLy4V1P1cK0uRFM19ilCA/yv62F3XA/gVM42/9kZQjcwOsBQftNE0T+MWLd1FSf/l3LHkz1AoX7/CfVPaMl0stvH3D7cRW4aUKOaoKHAHD3m7F/EoB1VDhtNbeRzRksj/68g0F1I+f0EntIsBnyDxHMG24PBvDevpQKqrpsbyFK/yboUcn/TeR2nC4xRGB0+4Oaag86su5T5d3woY6f3cDqFPULiT+00K5gr/SLEWxWVJuWeKIC0kLg2iQcK6yc4EnRe6cmN8rAIrWgtYdnwjzboTEXaNl4fLu/JTmYtsnVVp/DWnmAZB0a21cVFPD/NtsCzXuhRsnV0opaGyZNjMqOx4E5rv1t23kFSb8CXU6i4U3kX+Y5M4tWiBu12iY+pY3Tpof+JteZORni6lCIg10FL7m6SukITIIeXv2oKq9kfKSz3JADJ70HM8SZiTO0WB9Y6ccvsrg9R3I3GxlbRT7q+Ytfa+lqIZ7oKkksTs12wrcStPKpNN99oVcnzUAuxorSLryNbeBmOBu4Oewy+PXh71G4EE5pGAH3d0ohXdRxqPwbQLPPaz0fMWhHIYeEnZ1/Q9Ll9BqrL0kJa6x0hAKJMhCwEgHLwkttlGLjYGGoDNSmpl8/f8/nrfUs+8EKtdAm4A177g/KLRRXsuur8rbF6OmuKPMRwenC6MA2jsIEk9mNwCN7rS/axrg3vBHvRGBMgq+nyyIzM6hB3LE3hkBtmxBJ8azXnsjqvqYUx7A8OaeBW6uyKYWYcM9eyOUrFvTpaspRTbm9v1p4ICFwd6uM4fTbDcUoNlV5F2S24UpC/y0li3v/7/t4FGmhPIAKMJU7d9eKhXNgHCRfx6YOpjO2wncOKLCFxVoAcvZr8menYQ6b+J78XLopoWKMRZ/FfY7getoxeZuS6+FuaNDxBzxXREDk8TJ0jc9oTwNm55sefs0hIMTbZpick6ztI8lGrTZgV+b9nhzMPqtVcXYoKJbaV2gckT1K2d0UjEtYAj9LNwRlLCWdGlSnYGCYPBdZcMJCnQ5ULW83+VCwXIcwR5LRAygbd5BXrENXq7TvOUSETzJ56tgMsCmEkXm1khQRlVoD/LVwawE1r4KOpIZtt1kgVTrKTSM9Ljpftvr7nkTXHpXvt3yRYesjIWkMrGsfgQiF6WpsURZ85+3e5Yxecx6sxTATG32VKQXa4MCwhxGj\u0000","level":"SL3","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","iterations":1000}]}
\ No newline at end of file
@@ -0,0 +1 @@
+[["5A1DA7E05A1DA7E05A1DA7E05A1DA701","webforms.WebForm","Validated","example.com",1400000000,"",0,"N"]]
\ No newline at end of file
@@ -0,0 +1 @@
+{"SL3":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","SL5":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","list":[{"data":"U2FsdGVkX1+O3O15EG8/+3QxbFrwruZCgxztJmaIrUPftz8d6R3nogKi1KrfuLSi2HttWtUg+shgVUBtPC89T/CZi35Gpy02QCnN3IM34qhDMwNdg9GBmikWXvxhTDmjZNybZxLHYEQL0WYPrN1NSARn4+3zaHsq6U90kceyWqOtpCRy6O3lY2YiUGb4noJWzFX1R/uPoiju+mZVA2myIChsFcGiqwfN/2T/rOT0lj0amc00/ryV7Lt8Dd+cW4b0dXgGHmWYwTsvN4g20dcODvyljYbxiq81Txy1QvwvPNqKXSzuUjHNdCrZV5ZQEKY5zpBmQUt4FDK79NmVcz1VggzE6v7StmtR3nvN6F6NhxI2lvZT2Waacs50QLPZqzuD14LA+AlIoISPtf+Ejo6eD7jRDoBXkPResNy6rBcs1OPGFmSpYVsQAPURZJZ69rp/gZyOqdzTRdJGIL5/S74M60K2JKeNHlqsTTdxYAsBE0Oea4PeLZeLDnnna2K/k4zpsdyA6G7LfxkbDa2GZWmRQY20QjEBlkgGIR7KVbb1XiZJPUESqUtfpRnpp3/86E3im9JGyGizarTSqPVfT1n7D4NfDZHUP4SKhNR3Yc++q/B7v5t+oi9lYsoXz5GrcLqao8qYBjNosuNUwuBQUaYvKJybUHWCHUf9SCtL6vu6iaD1bgZnQDSrCPhY1vMsqOYo+Vi/NHM91WCMZSvIMQR/l7zg8/TB8ClU7Dqcg+TLx7bkt7rPB62eEzP3r3ndfjexr/yi3W1+Viz+tGh2enI3CUb75O/aXCBbGpv4m+jawkpkN3Uw7UO/rmgkNGauZ6wpTTGTzCm19BfvRpKCZpqbfnjXUIv1p9xsUlJtS9VBmVLwkN2Zccq1T3zeatU73GbAiE80X+HknC4ai4DS40SEgzPjCFeDVGj/jz1kN8lq9/fA2Lw75OyMa2VHXww3EJEs3z69fPagWvV1nYQUTBLwU25mHSxCN4iw/5itseHx4SEaIVQBf67l1hPjQopMtGyTk52kG7uHK5wZgqZFNqCOGUeodsXwvvHH8VgoYmLbdxxMR6HC5OvNGzYtpkqpLuEfutuXpVFQPjenpnCWZEa3pAn4CpU9MHzmVXbZyTYQsEKuSx9+A4xDYsFMl7x1LpfnbmJ+WXIgcccAE6/BcbUe1xd4NzOwEv9xYSouoBKFESfEVKa0AEDzNi9mXOsOXXaeqIMcaBuhc0idaSHO+35m+edf15qEFTRk2RGnBbr2Wh5sbC06cdo58j4fWwe/v3oQdSC1fwVieZnfQ6Nwprdh5wiBe9yhhVJ/eoTpokwv3xX9gNq8MNMnDo7la47oYIsZBw6lkzbgwmZu9nBFw1MIKP2z0LR/NhOXj4MoJ29q3XwHCllX2agaqeP89CalIisZ\u0000","validation":"U2FsdGVkX1/JSnsO03pdtFRmF8FroLtrsrJZK7g0dWFGSqLUK/OOlLBbHqoX1l3jezXULnXxO8pcGzATqEXT/qRZ6Af59yh3Te5r24IuUA/Er9ZCIy+ZsBQJRhprtUW32SEXWQYgOJGVISuR31IiijZ0STLCJdhS3FHHwZIRsf5ei+fq0qzgA7a6AcXAGAW3gRsom1RsMqmNYHbegC/CO5akqxTDD/pWjBuApVGXQSnOROyrLFQFde8nxOAPh9vRkulTORD6khqZvIcVlDdUhYfU2/RnpBfxGqhu8HxA3auIl/Xt1vCVRT0KczTnvcM/9QQ5F0ntUbn1RlGM20670p/u80QsUOA0aMTss4rz74AYaae87Lqu2G8vRKpxeqWNHJxcrxEpb12ezUEI1hgq2si0I3PY/8njWbbC5O1p+T0se0KSQEmxqFtqgFfw5f51CLuN3ejm4VDItdu2hfcN4hLsiobwQT3W/A3NzoICVr6lqf148BRlzirQEZPQrbsrYMYjo+nKZSW3ng2vfga1F1uE3TuL/8wx6/iK8iiyq/pzIpagHUDFoyVAQkm/zhhv85tkYLVOVeVy0Knf5pKSPDNL5IyTOaL+9vtSn5Cg59q4+FGj6KSGpJLBELIA73KEFaeJo8eW8KWYgJcY2DcodcRmpimlTh64HWQgOpAp9POs1vT4gb1bex1YH5ne5a3kRCM48OMN99kXvy2yAuuUrzVkiQlEkul26D8cYCFozstQT2CUJpi7jt3Cq2rroRt3loFJXsb2Ho+RI6FYZO7LXLWBZRFci7Kc6NZzWIqIIUbdHmwcoSbxdIieiHUqAG7AEti5PsyVeqNDxH8MtIkHCXruaHZeMeD2gEGKvT6zobCTflaQ3wd1fg+QhF64Kf/foL1NzE+UReeGLSqOqkm+7dWh0caU0/3Y19Ys502R5IkE6NIht4SpzK7RjoY5kXAlZAO5N5OoKwP+3Vq6/LGOMUAMj44ddD+r8VRH8xnFw/AiGo8tTjD4lZDXu4EmOa59b6LOJsuVkuFgi6w52MOlUxPhwzI49/ymYHQBQUGC/Y8IZ9LWte2JSCfrZfj1VIm02AMiAxxaM39AKLnsCFaVclCd4qEAzcPyI8Ss5pz/5T4FlDXhyMjzxH95LbTk53xlCRHwoVYhXjhoypBbygYbVM9pUeP/JLaPI+C2XUrYxQ38F6Yl2O0hC1LDPH3W5n1sax6ywckWcWS3heWhhyXtw19yPf3gHSZCTQLQmfcRMR9m87dHqdVzAIwwkQCCwM2ngu1gyCytr1qp53jkesHla9bqsLJM3+saRDygYBau7D24WJozEyDHwItyj8PdEf/WBLe14t7Va8g3W816vMEUvmqe7l1QjqZLj3/xjjYzzY7MwfR7eFl9Bolz+8jUOzmy\u0000","level":"SL5","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","iterations":1000},{"data":"U2FsdGVkX1/SkeIAoR6xsmfpMdVov8EyUa93WcJXYDCKbEpKY7s0dT2Lzkvhb4leJL7KCdJJxi4PDUpV7+y/8eSW4uhjLFeEzXiUCgkrD4KhHj6vNvZwGZDHh+locSwrXInlJe7furkFpsrqHyY+yQQGPPecEJAxEe3OtG2ma1KEMR3EVn1LG+HQTOuSGHa8Yb/Ww3dJbn94N5kJFdfdY2Xkhnr/omIaMjfs4JxBQxTw7CealbDzKcMYxqbX7VaD0Y9rei2raHzzgFarDQ3HlyRQafwjnZb1DVujKEAuNOkOo+PK7KuRLTDeB5FdGeVuQvxp8olbC6fWZkM8OBirrhk2YwzA7PFkQF8v9yU1KUS0qnbX9yNMjDbbhF1M7eiWaIqcZkXVZ9+8lXjcMZgfqVevAz+eFDQoqVnxexH+sirKWAl+vfW+NXghwbPbjULk+XSG1INujvfar3B5kKfb2kr1RacyGRFtl0L5Vi98xiPihejloK8dL1anhjjme45AfHRy3OvCBuARGpDn40uZpct9EA7mkWwI3KYBQVEPDN+EghvlugPeTtgIw0v68pHhETICs9ojjCpzsxfBlSmwYaUBUQRciFCJdMAvmTlgr8DNNbpnPjLmyYjIU7KdimQoYTLXPw+250vkQnHq1tB0bhOOKLrfvLd6Yb8kugOyLMN6Foh4m8nKI+QleTP/Pyp8+TFr7VKq7FNQ+f5WK9Cnn3gOk/z2NVOfAbqsq0VscI+oqr674K1F6JKCLI2WDQf53h0dgSKEWWNOpeuTN8MA9ycwbfBH9/nBRDqHFATRT6n8JfppwfpkuDhmNtpmRttzbmXs5NLQaeVNCFZkFry8nmA6qKgHQ+zf7zCkQc29s5zignS8p1CSWHQAkACD4Fq3HDnGqoRPUiVWNwqohC18BZvmo4QmnyZcl/hsn8xx1FbTTA38jBLXepO6XK40HdMubEgUoUUVhA12y+GmHJoejjb1eASj/AZ6QR+h6gUhQ+3Qows6Bqw5dXaH1YeLWh0aznK+60sKwygzu5zqIc2oN/XhPoRGhDcWjJMfWOBrzE93ZQl9J4rn0owDTp7wMuiaZ9eKkQghdmgTL1nl/ouZu/ZfX3LswgcKz5uw1/3AnaAMLZvAO2KXQtprqoIKGfJsO7hXJOJJ5MsnC4WploBEnC/I7pzoVI+zK+c95LFjU5cpPVOBPceXKzbsFl0NoXSIp2w249OllDsZgEojCow68JHOw61/QVGbA3qIFN2DxEczF3PocyZzQ69Al/HTkOm6nKIHp6w47Xi8L6x3/q1M4atW8FX87oOd9ilHuESGqhz1zu3Y0R538hzOayuDOqL5Wq9BOHsuPz7oCwu6ZcWXCnMWuT+IA/mnrsSDgvwCOK12BHEXdtGnGNVl7PL8ELKv\u0000","validation":"U2FsdGVkX1+3zjqIZA5HWRDzKL9ehhZ2UqJxE7G6mIPthwvIRQ9ojMgO18RubGrVfn/pOu7uTJZe1XCAVQA88vxeVsbiDzxfri9nutGy8ZNu9Y0hjWMGLdFMesppTvb9Cp3y3/HS2RAuwvn0lplx6eF9cWNK1JLRU1dqTNn8rsSd5AwyWWf+ejM83IEtugpLxvuFw6sTbhWg0nL5zIqkav3oC22MjYUzSgo2nDaEXubipaIwqLkILe2LG8vPdx5kMxLZGlWK56VUMJTqb1AP7fBKM2hReQbKQPcq4KCBYlA0R90/4NSuSWSqAqQXnLCNAR9CuTyqNxOXcWdLZVVFodquOCoVAugdm657okcoXYPriapW/FTqpt+q5xJ7BMxIa13gPKjkyFZx/AsPehdGqRU6eRS8thUaWqvtnQ+QnUdG1LFnrLENVa753Awa/rsykEB3QohCFzMpe5Vm43EOtVuZpNnFBAjfkCTPPdnCeBU/cQyoT1Yf1sAdFws/+WWwcbknLbdqd5q23wc2gos7dXWpUL9JPBa8IaK8AVeh3svKwzHq6rcOSk+K0FET7IAll/fJzZIZwxHEM1S/MTwizjfGGWmPcK1eDUpcMjr+wBnNp5aNkLQWHFfM3pybzeJC2foAdFSwk5E3w2KS07Tmirz0Tvw1P2ap9aC/rUaiCJDsWQFKY4aqhFivbGUnZr8Y2XJE8JlKZGMr7oNd9x1wPYax3d46Wkp+Qf7rnVEiOZFPJXpkGUNrTes+zpfpM9W/SpnbfryUYUKajbXFENT+k4+K+BzEiZVYugba3HYELiol0tIPz2zD9BLfzMSAVsqYUexCKkIxzMxWfq+SHVw/Py5YWyDFkr7Opg3xt4G8FkF1+otNOnp20MJJk/jDxJT52JRjoFPk1we1VLxe+tRclc8FIBiy0IJ2doqELgdNuvi5ipi+war5L8Y0zzubsW4IUr9x82ihy4wp7ibmYAy7w0duRKari9qTHqU6Fs8giqPni0ya2fD5uhFe1ChEJlKRDQU5OqGHHb9wvz62eWO3+hzjmrm0Ir4vIERcP2Q/4T+ImFfBOjCHWQwVAhb3HQG+ixf52OUaHd+xXxyNiqTze3q1kPcX2oiThLJO4CN50FDPPjixnOWv0EceUwQXR6sEaScuzKttRXOnLXEYgqL0JSBudRRflAbkckYFW4PzOEJEku83EzIq/oilJiiHeyCzyuupikEDIOXihYNLu60BtSdmlb2+hvmKn4/5xW4waIorym5DZus7phDe+btkIvrN18i2+2IeNtnK4/V8W6Ng7T/C/g2NQKIx7JOFDKvO+TDswGJgxgWrqhb1g88YBd/fCOYiqiASKXJPZDpOH5YcN7k3E7kOX80YUcMnU3ySVrr0QwLV7j/hpnUvFhkt3tpl\u0000","level":"SL3","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","iterations":1000}]}
\ No newline at end of file
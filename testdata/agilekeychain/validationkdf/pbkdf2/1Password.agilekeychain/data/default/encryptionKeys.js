@@ -0,0 +1 @@
+{"SL3":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","SL5":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","list":[{"data":"U2FsdGVkX19JuVUPA/0J2rVrTMB4xYDfVBfX6So4erBhOjWJu3johlsmHFW626a1MJxbGUpUaC8O1Mairnotsp8G/HfMl1DxL/ZUY25BvlqrhX0fv4cBiVY/NKDJ0dFAcSr2izPjDVElb9twloQv4heB1yzodGizwkkvfIX0AMGMub6djOGo+UzY+i83183pwb6O6xjTiFyEcRe7z/Vj6szWT69ozLBl77ORQ5obGI8PAlPWdN+eKGlQixMloZ5tDlmTaQn6G9My4h+tGYzg5p1K0N1OFmP7sv8ftR4Yl/CqRBPr4oHOuHZQ0zdDndHeUBoGem9mWHozIsvhzyzqX7v0vIrFCxRO7xzcJJ61pzHHwBAKJ0AaqQnnK2M34Cvtmut+k0cRU3UTagpZiK8acdVKk0DDLsK3SEIY6NmsWDGPMe4uHZL3jD8aF5rbPLosdPnbH/yxAo8BvDBZovCR5mPKToJzZ8cXhcCq/ugHAF8g9sch1uLg6u3tuweDH11TQHHGD0Bg3VY0S/umUD9aZYA5fZWPhBMUl2sZwjSaDPyDVo7stcHdkxv2tWNMdf6JfUM1Cbh9JZytiWSyklpz2risxm/mQ1RLQinTyzU7dSB9Nv8xltjJ18eFBuIQMmCCjWNTGH+CwW8zrtX8rgWuaA2Cfi/DQgwlLoTl512X3ROuKxZbh1OBXZzUIg4yGz4oJDMdGIzc2vOr/exJR6hy5rRjZNAI7hJavy0pcrHbWFyaiXGrFL/8IWtEQAPTHI+Bq+zNyRgytpzvj2D9ZnE4ttixczJ6IEVrobPqsJ7RB/OnC5dPbYkOMbamiTnmAzX39/ing8NYa1QGFFr96mKsYX3auJUobTFB5zbhpd3Vn+HLLNQOjkPSIUYq/Z9l0fqcgewo9w1s4mk4nx+Gbki7fSvBqVNE4qIOSPrVcYdggsU5bGdHeldZCUwolgaXE71RG1oFBRPiHRXdottLShvo/8EobK8tq5HtR3FHnXSJHqsIMMC91cx/8x6d2JA3+wS4fBH79Hy6az+JoMjnv9rM1FyEj1iC6D2WADJnJOQA1WdguNUvkvf4XCC+RleXjR3mIa3tAlyzyYS6wGdNz1azR8fZZ/7Skk3MBOhT0GlHT/tNEaxTvQnyf3S7hSpoHIDfQ6ujp7+QB9NKtVfaXuCXX4MQ07SVo2lnwnLAvACCNj/WHYnjME0+L5hnEbgW8PmxiE8H49+i6ukIvKY/vA01RTXe0OdHeA4Y9+EIUo3OoemeHKIBeJ8iX5AvHpp4m3ioKD3CM4pKSO23Ty1g2Gx/xhxSx5TgBBrRh20trOTXdvdjBuLP2wAdjes7kLCld5L11tLTtlQoGXGraN+LaGgA8+6zcuatlcNjP5tCgihloCNE+SrSd/zQAsX78cT1oghI\u0000","validation":"U2FsdGVkX1/zBo9YvEcrpVMyev83V52phdiugaFXKzbgFMBmOgf7GhN1MIPJBGmAhilXbBZyMRe2i2WISrx70+iLPlv3AQFOZQ5s23TA7ZSIT+VEY1Ejz1tK/aBaCOE0Ko4DJxwY8F9nPnfJHOpkeG1r4/hHkuqSPwMe53hNj7QyRLBTnlcsU1zFKvFFjyYOfbz9U3I3MYLvG+++6l6hdZLqDGETX7j/PAE64ZoXibCritOAhwhxTropwd+R+dutm8vlSu8ODahekTu1NE2GotNsgOInehH639ObFYraz20rtl4nFxLi/Ztc8j5kiu8UioTVxSLrhT29ew+VPRXJ1ZRxWLAeV8apIXqgiKlR3qtX9nPGQnx1oQjf5gZ5oHPb5njg4idfT1mCuOKHmfROs5MDjH5XPsbFL/4q9EIEqJgcjhtpUoZXI8yLaUXewmrfXIOAFg68aHbtE+oVJNJ4kVRK2eBkKcmHcTztDD0ujLUQK86KPea3LFJPr57yh4dxv27mmjWgCP6ARq3e+2yGSG+LWHTRr89l2GJFJTzBy4KAIDsPExfX40j960HE8hQyhgzdmA4JMsQ7iRad+SCIZW2ppKpgyfMVjxTJFhRUA0/QuW80IymGhxg88DevYQ+XLQpkj8mHLeEqmIgpED6Lryr5y9VbeaA9TlOsC+XYx9bUEvKdj534l7pDsmuMLcC2hwiltOoxH2+20XVDvF4gO08YKVqncUpaQ8ds2GRCj1xvaCUx+8ocRr+yvb87EzXjl55lwfcwY79AbsBBVIElt8lhhR5ZCXL+GP8w9YtRHpuGnVlfO+RQNuvSiBW6maRPNxJQuA3ehozzUdnLpYBKTeSYIogFwvaWnVPud8W93ymO5BIySb7q/RluyDVeJGCwQMaREqn/wjOjV8HH4s+wWoxTfa8Nlxa6AM/ykoS716OssKTu7vsRxAZybe1cVAfvV6TaC9lz/1nHVts+WSlJSysVqCuk1/tnL8+lVHPiJvbrf1L8dMfWSZR8V7M1rODc7cSQjzXKI0/FmFvZqulnIxKCg5E+MAp2XhuQ22x8pqzvsJWUN0Enx//AkQfi54eaZ0DW7MRC+/t7JNy+YIGaF2CHFEQ/Mlriuidkpo4+uLi337A6BhzkVlm0x7EhUFg8AYk0uwhev57zbe1Wfqy+INs6H9VrIoc0I9EnAokchEmVlp9ogVGUucTRt6VMC3PA86eSiXyZCCe3uRRqeB3voRUEEUROPYpMsARVHZuKqxuDepKGZz1j0erAryhMXcOMS/XCCxiVgGf1XRQoAkOhgahZZ5V/YRKKHTjFjIteWvrIqzfP9iUlfg5CC94CokOgWDQ+AiyfnsfJTgGVD7g5Quhk8r1DkDZZr2BWxn/IH9VHhWOgBxc6zOw3hmz3wXIY\u0000","level":"SL5","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA05","iterations":1000},{"data":"U2FsdGVkX1/TZzZrzK+ilg4YXA4CVIWqE0R+nvZNnMTUOHbkh+CLJgAFRXYilJxLkb+bBPwFyLwvf8nU+m9l3KyqN35bcSZJKv68MAOrEFW/eG4ife0Rfv3JsxqWwt5AftNRz0IEIbsSklNV2LjDQ3GBdYPVCZUyPJmpYOtIkkXf1OpXaf8E6BSdekW29yYpezf/j7bI9xg3j8U5lw+VnYbKDH9aiRR5dD7zzDHFxgMOd9JuzEmT/4IWUd8L+0+l9Pq+koSuSsgtfAKRuOO6DSGLm1aaynaEoM74cVoQKH8TsdfgRR/KLoLgIE7B5akZxiowGX4y3qwlGsVHDBnS6Iux8EvnVdRCd6q3fOjAN8Juoi6x232m0UINM+Gp9Vb6UfbgsvC7TC1ARLo4RhpQQtHB3w3qwah6vkRv8KJek6yEUFV989BV+pjPRPvTkeAtjeKAWjMcoa24e0sNqKrjRsXd9mudcwcx6tDobzXbKiMvWG5nOZuojnoSnYa3ny4g1CpFMLRXLfR4Ykuw7es5RIE2na4zDldTKvCAGta2ns2p/IOQqbUmsbNsY5+MtOweeCQYF1aMhSU5uVzuC1N0NrzRNDBkl7RtDnrQSyYqEUf7JApg+cz27yS9OMFnT8tJz8/HztrVJwzizrDm/SbXdEzzxrdsqc4o+fOcOA8KgRSk3r9GI/bmhDfT1mY+c+Rkmn3e75hlaDwhrOxvzn9t+r2Rcri5nxxwHY72yv7nBnRd8VsQK6NhAqUR9Qa/LQxHVrWiqxtUskQIuOrTccUTOBz2DH5ksL3J4kH/j5oqRbaIwlN7WUTHCNrQEuH6VSQGe4y7DV9N/DadoQLSmPwOKE5L8JHeN4LhNc7Et9Vm0qQYEhm/WCsilTTZ2SxRVccoQm1CftLzLqm6Ha2KAhf3v8tWkS4EaXfNsIZBwWXA0FUh4hTM7s3Vapo8oH1QUQa7d6F8X3yK8Qyt75uOmCkCRyRQWZT+aG1/aPFy60ZLHljaouqalQSXBxbeivmmkJWvG/by4UZKYsZQy3h6kAdttAz1f01LVIUk6uTor34YBfV6wk1uUVEOyHxUbckVtBzL6DWFZSt2M9RSDxfbTcwHzf7PtWU0t82RmTek7xgA6ftCUlZbPpinZ28RSnoYTm2Zcef7giioDNsk2rINC5iBXtNrjM503WZ2DOsZ3RSQLtCGN0XvIyLHCR0+KGUlPVOZrIkRfgQwMK0X8U6+zNMZ5D2EetGe1/WlgH9xwFv7T6IlbpkKTLKOJ0AeBlN9HdkP+VAVe5ZtWIXP1ztlrxziCrPPTQs+AJwxK8ZzEc8MOiRxxOzv09s0g7Fm50PnFG/EYAxcaJ0MKmD9iKH9oGM/UDItoFBT0gUyPMPjEPpwYqFPXpmvkl7zjW0k99X/gajG\u0000","validation":"U2FsdGVkX19TYJcTVfRv1eyMINglQCsiEM1OO/RxRPLIKgIe4k0c4JZre8NwAPDHdFzi7mChZwd7NLrFbTpWnPtxmyYas4uYzG3bdLSReoFm+x9bTbBoBpUAn+HPIiL5nPj4P/777hSutesj+QlIdja5yUWWhxMoeTbIiWqyFKW0PxGPnF1FV+/M1v9+ykFODR2PD1dLu3ZQ01E57hzTS++nHQqCfnvXU5TIhGmA9UYpQFch7SFMCnDyldcaEhkq5MRq8S5/pN2byd4jtEAValMvj919LFeeDJvAvpoWXgd6mXQxkCYH9SutvnoAz6829HlMNw6oweD70A8mGelTcNbEGtHXCyMFLwwNvgT4LlgWNLImEcEmN804Y0VFME95DcSlbh7/GQYEPxYv8SDug38/vuyPxDTTRg0FaFMxlq7jEVIASgR3s6QDLXJtgSPnHKZwi/hWQRkLshO6PY/ZSOdqwSYqGm/ISRTvibTvatjrUba0tLP5kxeCrrwRBzWQTStob8/NlRV4ucfTopmY/GvmZ1tJ1eSMUkC5nRdJJJWcTyxK58/aekqtLr8foJj5CvQju9dmjBpbAp58aBIwkPcR4MYBTg+F+n9QOwejg6fTL4VcZu6ueL9rWJkNa7gtbTnOEqlyIGwK6ilya4piNGef/PYRDo9k/fga8Gb9gH1n/hCErmz0QB7qMHx9DSCnorHjFSN3e2KBz5YyQYiSg9fG9Z4YZWUV5xHh+CAHQxLbr5mlaz656ZbSRoHTWm+SAqJ2bz00Q0fsuNaEOLpX1MyorVr8ae5st7qMqRzCJnNyGBE/p57tvq9Y/+pjYK1XQI+uC1bu9GhHqc+oDdLZwgvrEX9MEeGis2ak8J8P70ed7rYV6n4yBb3ltP7MOJzhptupyBbuvTNrGHbZ+OQky8wRgGyXg5jPlizLEvpffqZTgNfPrF26bbmpNbEVHAkl32eCOev5t9D5Z1E31+BVFFytTRyb/yaqZilPUKxhRNmdTXYd7VRp0OUHYQXm1JE6IskaRGTFebQAdQYKZxyM2ZidzX7yzQt+t0Pskhp68P1M5LHnyCmdTLUTzdN5rTC7OCNQGKk1NYX2S1MyJh80Omq2qKvg0UR0WECYiC9dWKyNs6m1ylnztIjFRC5gg2sjWBKPA3u1IcGE1BrRJVVkueFbEHzimrkrvfkFLvtodP6inqH5wBN1F5gh1EPf3SLJE2pe9IUsv4llqd1Kna+qfbyoFGUe+vfbjfL1wociOTE0YY2/illMkpdAvjJa4NJrPmHF33eoYVZ7X6E2O837TAQS3dn8t9tD7libfJ3iX1xl2UOB/Tun06HGmEqdX55qHttNyvEZKrmxGo8MvBRGvIj88T714s4Sr0lRszXID8YL/QCHHXDj6cpBX0TlToDS\u0000","level":"SL3","identifier":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAA03","iterations":1000}]}
\ No newline at end of file
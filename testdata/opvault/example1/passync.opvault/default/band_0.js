@@ -0,0 +1 @@
+ld({"0A6E4B7E2F8D4C1B9E3A5F7D2C8B1E40":{"category":"001","created":1600000000,"d":"b3BkYXRhMDHBAAAAAAAAAPSvbNBoUS0f/GtXyLQqy5fxwkZp+BRuVGs4JDEaLoRc3sUp2LxNXpfLJZgwqmW1NK78/zHsUpcBvsUfDHfMrSSvWwzOPYrcRtyNaTJ1paaYgatAzMoILU+toW+QKRxLzIxfNMkPHzujKfnPGPFsaQHGqVCMMPabiv2Gd4W6iP6FaJ3MEhkFPxb8+ACT4L9CMeXNJ/Ls/2sbZ1AZfR1ds6IwvNCMQqr3SXut/ok7ApfmO8FIzx9h9sbMUN3U/8H+i9QLxV96EJgjPIL2WPk2xO8ih9IgLR3m8Bc49Yl2IYTw2m88FTzwrBVjlC+h2OxEmeksPfQJHEo9jfmiMXDG5gA=","hmac":"M+olJpvPFU1LPZ2WkYFCXBNjuIfzddfLjkZIDJOa3Ac=","k":"+sLc/1VO0SGioOSSNzakto+1a+HeN8P27qR6T/ZnEisDuMxmU1GiwVN8Hm4qIl0et09QAs2Dae5Sdt9+0HxPRGeo0rb522FWgh717Kiqerjs/xkcSryUIPoy0LZQGQ9gti5KOtuCG2KwFImbdTXjBA==","o":"b3BkYXRhMDFpAAAAAAAAAOxXpOd2t6wFVbyQpY2KEV2Pjzue0fNrvGZVI/qq34TP1dVXR3Bk4/tYwwgRLw1IKEBAbqJT7EFD4VYzGk/9ow0HRwD6DpdfKyESc/wzJuMvjmSAmP2Uovv6lmLcklFTjx3C386odw/2Vy1AIyaB3PRicefbajQ4vjtYFpZaIle7KC3bHfesf9S3Rrv/BD0hjX6jc9YdeB0iPr8ORVL4ZoQ=","tx":1600000100,"updated":1600000100,"uuid":"0A6E4B7E2F8D4C1B9E3A5F7D2C8B1E40"}});
\ No newline at end of file
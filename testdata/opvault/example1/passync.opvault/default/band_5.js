@@ -0,0 +1 @@
+ld({"5F1C2D3E4B5A69788796A5B4C3D2E1F0":{"category":"003","created":1600000200,"d":"b3BkYXRhMDEnAAAAAAAAAAX1Q73pyrTmtxsxBGVZg8Ec/vX3bQ38ZGHv/6vVKtZSy6ZiQbAwwqCAwZSQw/O9VLuqXYQE9YUwrkURZVFRioNeeo/wJDrQ0LGRYPeIf9HCkMMo8Ogpx8S7WjK1fRbFbA==","hmac":"OHr30Md+D5KN66SzeYHEuDdZr/z2R7+zyDKQic3zA1o=","k":"L8fyYQXVUmmiJ8MQ7mYBtriP2hJaNd9JawH9QOJat3nDP48nk6v9wmxjoq1Y341SDBVgO9GwRq6RytJVqKWo18An4N+kQalMqCWBybla+yVQ0uzjav8ds8B5EKKW/auQt9Bd/FY1A712F5OvuNi1Jw==","o":"b3BkYXRhMDEoAAAAAAAAAH8lF5x8+BPR+Rii8y/RhzlGlk7/0f7lFxb0L7k6dnUXAc62ggFajO9H7t+lyalubKN3sd/qQePXxDQUwlF7phJijs8g7Qo2OZO87q7ioR0h03QXKVKGxhtZb/pUB4wzaA==","tx":1600000300,"updated":1600000300,"uuid":"5F1C2D3E4B5A69788796A5B4C3D2E1F0"}});
\ No newline at end of file
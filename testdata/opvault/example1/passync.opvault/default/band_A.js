@@ -0,0 +1 @@
+ld({"A1B2C3D4E5F60718293A4B5C6D7E8F90":{"category":"005","created":1600000400,"d":"b3BkYXRhMDEWAAAAAAAAAFtpmqJbtKs4zxfaj25A6A0KrPs+XUy8mer5eTKqzka47mb/ncrh9G4idltahwAoD3bsagAA3COyAHwmLT1mrx21baAo4E5AewRFJScvHJFh","hmac":"2P5J+UUfA1IH/7YP6NCdi28Yzxy1IKomVpEmL9jg/KI=","k":"dyVOOnLDV7H9VCtQnBxTT/RqtERJFyUEmuuIU4/5h1AkXsyuf9R3G2c199w8KUoWI5m136nPJOMW4IuFOGqeR6Y+2+B/engY3w8jM0MAezZwnlWYpF6LjlWoN4pLSmYfKRPyt4ygZn6gwgS181smAQ==","o":"b3BkYXRhMDEhAAAAAAAAALaJR8qZ5coRjNVzpyzOIYDIG6Hbh0IVnpO3uPRPoXmyN1N48kOM13nNeWBpgkgDhkECSEd8byBh/VUMEcdBj3nTsMMewdqJK+czdQ8IN9ZZngNcDn3Mt+bMLpJ8qfdWsw==","tx":1600000500,"updated":1600000500,"uuid":"A1B2C3D4E5F60718293A4B5C6D7E8F90"}});
\ No newline at end of file
@@ -0,0 +1 @@
+ld({"F0E1D2C3B4A5968778695A4B3C2D1E0F":{"category":"001","created":1600000600,"d":"b3BkYXRhMDGYAAAAAAAAABUgdwtYlo/msk2JDzBL9QClL98d9VQP1lZsd20fd3j7fll7Ux/58ZsT0/F0GoCRcP4pX/Ej8IObj78b7NGmWPf6gv4U+k/FjrZ4afsj9hjZuqKXSC2WocHUAlYNVyJ1o62PjhbgW0h7TBq7wWFa840Gn55CC2/+jCuwWRUjBEc3pU2+5JpV9bqyq/HzglBmxltY3RvMb2cMa8zzO+3rNNLw8xWOCzK2jFjqHWqzYpmrSfLZonHYmVvN2L2CvhVJgdeVVd2c4KOFXvw97eitEKg=","hmac":"pSaaCh780ec4skL2ba+htIXZ5bYEercyovTStHOoY8w=","k":"aSr4pNglQo2hbjUmNuBxQBzxjcjRW2NH65eXoJjbBuutlXtjiQMYMnrmzacVsHNW9+gg+nddwkUBtmhgZUTEeLqbf/ACy3dz7qnLakc1Wd44Q1Cmfi2mShU/gbYq4LeDOKstw47iLZvsMTnS09JeJg==","o":"b3BkYXRhMDE2AAAAAAAAAF5dVG7nPG/1W0PsQcPw07YLvGf55XE/azNfB0VXfOaWM9BG86VqPB7jXAAluAmV9XNiWziCqDSiW4SVnny2I9LBoOQ7IWbon8oWC8bxDseczmqV2KYFBSx3RRhdLRHbnwRMcWQMUFgfuCCIJBH6Hro=","trashed":true,"tx":1600000700,"updated":1600000700,"uuid":"F0E1D2C3B4A5968778695A4B3C2D1E0F"}});
\ No newline at end of file
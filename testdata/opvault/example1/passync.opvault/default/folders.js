@@ -0,0 +1 @@
+loadFolders({});
\ No newline at end of file
@@ -0,0 +1 @@
+var profile={"createdAt":1600000000,"iterations":1000,"lastUpdatedBy":"passync","masterKey":"b3BkYXRhMDEAAQAAAAAAANIcrxGr9jd4oe9C/pJzKxdBtCDanHkZmwsGHPOwMXWw3T8oipvznhQiAG4MfsfULAF/Q3+0oG/qpH7zFAgxYdhc0fnnZonnoXo0XfX3/sgDRTAQ2QzHwTDppRNXjYNEOI5e0lys3ejd+27FN38qq5Yhy0kvgnOed7xfJ9kw76JqpgWheJlkRkc3bKoeOOIGh5ZpdBfxppKXDvPYNQeT9SZrwJFcHR5KVeBrjoOlTaMDIU3/ZFxXccWzHw/hatKpCiIf032jWrYFBm72UHi83ZRDLB6q8/+Wrek/VIPxbj/rmRsWoKuIsW29RAoXue4a1q6wizaekC7mmRVU6oW/Urp7T153RqZLcR/M+grlVIvXN7JHlD+ZbQ/1p9WRqfvcyGJ+YrA9376MdvZCTYoDvmdAQp3VU/AecMqkC9s/N9Qu","overviewKey":"b3BkYXRhMDFAAAAAAAAAAGdJmVwAI2DURP2IFZholg3ClsrIw7ySa73H8GrSBqM/h+COyY1enKGegE3L3m/gQL5S5+tBWp2dvmldCOwRgiC7zjC4GDp8iSKk+F4iVefIZMLraX9tPCeAjf8pMybVPcz6UOB8Um4SpXOQncxkEi11k1KKloefgdD9Aj5KiErs","passwordHint":"","profileName":"default","salt":"OHJ0guuMIk8KGqgQXl8H8w==","updatedAt":1600000000,"uuid":"2B894A18997C4638BACC55F2D56A4890"};
\ No newline at end of file